@@ -0,0 +1,202 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+	"github.com/scylladb/gocqlx/qb"
+)
+
+// Batch wraps gocql.Batch and allows to add statements bound from structs or
+// maps one by one, this suits batches built dynamically i.e. in a loop from
+// heterogeneous models.
+type Batch struct {
+	*gocql.Batch
+	Mapper *reflectx.Mapper
+
+	session *gocql.Session
+	filter  FieldFilter
+	err     error
+}
+
+// NewBatch creates a new Batch of the given type using the session mapper.
+func (s Session) NewBatch(typ gocql.BatchType) *Batch {
+	b := s.Session.NewBatch(typ)
+	if s.ctx != nil {
+		b = b.WithContext(s.ctx)
+	}
+	return &Batch{
+		Batch:   b,
+		Mapper:  s.Mapper,
+		session: s.Session,
+		filter:  s.filter,
+	}
+}
+
+// Add adds the builder statement to the batch binding its named parameters
+// from arg, which is either a map (qb.M) or a struct. If binding fails the
+// error is reported by Exec.
+func (b *Batch) Add(builder qb.Builder, arg interface{}) *Batch {
+	stmt, names := builder.ToCql()
+	return b.AddStmt(stmt, names, arg)
+}
+
+// AddStmt is like Add but takes the statement and the parameter names i.e. as
+// returned by ToCql.
+func (b *Batch) AddStmt(stmt string, names []string, arg interface{}) *Batch {
+	if b.err != nil {
+		return b
+	}
+
+	var (
+		arglist []interface{}
+		err     error
+	)
+	if m, ok := arg.(qb.M); ok {
+		arglist, err = bindMapArgs(names, m)
+	} else if m, ok := arg.(map[string]interface{}); ok {
+		arglist, err = bindMapArgs(names, m)
+	} else {
+		arglist, err = bindStructArgsFilter(names, arg, nil, b.Mapper, b.filter)
+	}
+	if err != nil {
+		b.err = fmt.Errorf("bind error: statement %d: %s", len(b.Entries)+1, err)
+		return b
+	}
+	b.Query(stmt, arglist...)
+	return b
+}
+
+// Err returns any binding errors.
+func (b *Batch) Err() error {
+	return b.err
+}
+
+// Exec executes the batch.
+func (b *Batch) Exec() error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.session.ExecuteBatch(b.Batch)
+}
+
+// ExecCAS executes a conditional batch with gocql.Session.MapExecuteBatchCAS
+// and selects the result rows, one for every conditional statement, into
+// dest, see Iterx.SelectCASResults. It returns true if the batch was applied.
+func (b *Batch) ExecCAS(dest interface{}) (applied bool, err error) {
+	if b.err != nil {
+		return false, b.err
+	}
+
+	row := casRowValues(b.Mapper, dest)
+	applied, it, err := b.session.MapExecuteBatchCAS(b.Batch, row)
+	if it == nil {
+		return false, err
+	}
+	if err != nil {
+		it.Close()
+		return false, err
+	}
+
+	iter := &Iterx{
+		Iter:              it,
+		Mapper:            b.Mapper,
+		unsafe:            DefaultUnsafe,
+		defaultStructOnly: DefaultStructOnly,
+		casFirst:          &casRow{applied: applied, values: row},
+	}
+	return iter.SelectCASResults(dest)
+}
+
+// casRowValues returns a map for MapScan that captures raw values of the
+// columns mapped to fields of dest elements, so that the first row scanned
+// by MapExecuteBatchCAS can be decoded into the fields as is.
+func casRowValues(m *reflectx.Mapper, dest interface{}) map[string]interface{} {
+	row := make(map[string]interface{})
+	slice, err := baseType(reflect.TypeOf(dest), reflect.Slice)
+	if err != nil {
+		return row
+	}
+	base := reflectx.Deref(slice.Elem())
+	if base.Kind() != reflect.Struct {
+		return row
+	}
+	for name := range m.TypeMap(base).Names {
+		row[name] = &rawColumn{}
+	}
+	return row
+}
+
+// BatchRetry specifies how Session.ExecBatch retries a batch on transient
+// errors i.e. timeouts.
+type BatchRetry struct {
+	// Attempts is the maximal number of times the batch is executed.
+	Attempts int
+	// MinBackoff is the time to wait before the first retry, it's doubled
+	// on every subsequent retry up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultBatchRetry is the retry policy used by Session.ExecBatch.
+var DefaultBatchRetry = BatchRetry{
+	Attempts:   3,
+	MinBackoff: 100 * time.Millisecond,
+	MaxBackoff: time.Second,
+}
+
+// ExecBatch executes a batch of idempotent statements i.e. stmt and names as
+// returned by qb.BatchBuilder ToCql, binding values from arg. The batch is
+// marked as idempotent and retried with backoff on transient errors
+// according to DefaultBatchRetry, this is independent of the gocql retry
+// policy of the statements. Only batches of idempotent statements shall be
+// executed this way.
+func (s Session) ExecBatch(stmt string, names []string, arg interface{}) error {
+	q := s.Query(stmt, names).Idempotent(true).BindStruct(arg)
+	defer q.Release()
+
+	if err := q.Err(); err != nil {
+		return err
+	}
+	return DefaultBatchRetry.exec(q.Context(), q.Exec)
+}
+
+func (r BatchRetry) exec(ctx context.Context, fn func() error) error {
+	backoff := r.MinBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isTransient(err) || attempt >= r.Attempts {
+			return err
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+
+		if backoff *= 2; r.MaxBackoff > 0 && backoff > r.MaxBackoff {
+			backoff = r.MaxBackoff
+		}
+	}
+}
+
+// isTransient returns true if err is a timeout or an availability error
+// that may not happen if the request is retried.
+func isTransient(err error) bool {
+	switch err.(type) {
+	case *gocql.RequestErrWriteTimeout, *gocql.RequestErrReadTimeout, *gocql.RequestErrUnavailable:
+		return true
+	}
+	return err == gocql.ErrTimeoutNoResponse || err == gocql.ErrConnectionClosed
+}
@@ -0,0 +1,206 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/qb"
+)
+
+// flaky returns a function failing with err n times before it succeeds.
+func flaky(n int, err error) (fn func() error, calls *int) {
+	calls = new(int)
+	fn = func() error {
+		*calls++
+		if *calls <= n {
+			return err
+		}
+		return nil
+	}
+	return
+}
+
+func TestBatchRetry(t *testing.T) {
+	r := BatchRetry{
+		Attempts:   3,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 2 * time.Millisecond,
+	}
+
+	table := []struct {
+		Name   string
+		Fails  int
+		Err    error
+		Calls  int
+		Failed bool
+	}{
+		{
+			Name:  "success",
+			Calls: 1,
+		},
+		{
+			Name:  "write timeout",
+			Fails: 2,
+			Err:   &gocql.RequestErrWriteTimeout{},
+			Calls: 3,
+		},
+		{
+			Name:  "no response",
+			Fails: 1,
+			Err:   gocql.ErrTimeoutNoResponse,
+			Calls: 2,
+		},
+		{
+			Name:   "attempts exhausted",
+			Fails:  3,
+			Err:    &gocql.RequestErrUnavailable{},
+			Calls:  3,
+			Failed: true,
+		},
+		{
+			Name:   "not transient",
+			Fails:  1,
+			Err:    errors.New("syntax error"),
+			Calls:  1,
+			Failed: true,
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			fn, calls := flaky(test.Fails, test.Err)
+			err := r.exec(context.Background(), fn)
+			if test.Failed && err != test.Err {
+				t.Error("expected", test.Err, "got", err)
+			}
+			if !test.Failed && err != nil {
+				t.Error("unexpected error", err)
+			}
+			if *calls != test.Calls {
+				t.Error("expected", test.Calls, "calls got", *calls)
+			}
+		})
+	}
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		fn, calls := flaky(2, &gocql.RequestErrWriteTimeout{})
+		if err := (BatchRetry{Attempts: 3, MinBackoff: time.Minute}).exec(ctx, fn); err != context.Canceled {
+			t.Error("expected context canceled got", err)
+		}
+		if *calls != 1 {
+			t.Error("expected 1 call got", *calls)
+		}
+	})
+}
+
+func TestBatchAdd(t *testing.T) {
+	newBatch := func() *Batch {
+		return &Batch{Batch: &gocql.Batch{}, Mapper: DefaultMapper}
+	}
+
+	t.Run("mixed", func(t *testing.T) {
+		b := newBatch().
+			Add(qb.Insert("a").Columns("id", "name"), struct {
+				ID   int
+				Name string
+			}{1, "foo"}).
+			Add(qb.Update("b").Set("count").Where(qb.Eq("id")), qb.M{"id": 2, "count": 3})
+		if err := b.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []struct {
+			Stmt string
+			Args []interface{}
+		}{
+			{"INSERT INTO a (id,name) VALUES (?,?) ", []interface{}{1, "foo"}},
+			{"UPDATE b SET count=? WHERE id=? ", []interface{}{3, 2}},
+		}
+		if len(b.Entries) != len(expected) {
+			t.Fatal("expected", len(expected), "entries got", len(b.Entries))
+		}
+		for i, e := range b.Entries {
+			if diff := cmp.Diff(expected[i].Stmt, e.Stmt); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(expected[i].Args, e.Args); diff != "" {
+				t.Error(diff)
+			}
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		b := newBatch().
+			Add(qb.Insert("a").Columns("id"), qb.M{"id": 1}).
+			Add(qb.Insert("b").Columns("id", "name"), qb.M{"id": 1}).
+			Add(qb.Insert("c").Columns("id"), qb.M{"id": 1})
+		if err := b.Exec(); err == nil || err.Error() != `bind error: statement 2: could not find name "name" in map[string]interface {}{"id":1}` {
+			t.Fatal("expected bind error got", err)
+		}
+		if len(b.Entries) != 1 {
+			t.Fatal("expected 1 entry got", len(b.Entries))
+		}
+	})
+}
+
+func TestCASRowValues(t *testing.T) {
+	type Row struct {
+		Applied bool
+		ID      int
+		Name    *string
+	}
+
+	row := casRowValues(DefaultMapper, &[]Row{})
+	for _, name := range []string{"applied", "id", "name"} {
+		if _, ok := row[name].(*rawColumn); !ok {
+			t.Fatalf("expected raw value for %q got %#v", name, row[name])
+		}
+	}
+	if len(casRowValues(DefaultMapper, &Row{})) != 0 {
+		t.Fatal("expected no values for non slice dest")
+	}
+
+	text := gocql.NewNativeType(4, gocql.TypeText, "")
+
+	t.Run("raw null", func(t *testing.T) {
+		v := new(string)
+		if err := unmarshalMapValue(text, rawColumn{}, &v); err != nil {
+			t.Fatal(err)
+		}
+		if v != nil {
+			t.Fatal("expected nil got", *v)
+		}
+	})
+
+	t.Run("raw value", func(t *testing.T) {
+		var v string
+		if err := unmarshalMapValue(text, rawColumn{data: []byte("name")}, &v); err != nil {
+			t.Fatal(err)
+		}
+		if v != "name" {
+			t.Fatal("unexpected value", v)
+		}
+	})
+
+	t.Run("map value", func(t *testing.T) {
+		var v string
+		if err := unmarshalMapValue(text, "name", &v); err != nil {
+			t.Fatal(err)
+		}
+		if v != "name" {
+			t.Fatal("unexpected value", v)
+		}
+	})
+}
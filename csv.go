@@ -0,0 +1,82 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ExportCSV writes the rows of iter to w as CSV and closes the iterator. The
+// first record is a header with the result column names. Rows are streamed,
+// that is written one by one as the iterator fetches them, so it's suitable
+// for large exports. Values are decoded like in RowJSON and rendered as
+// follows: nulls as empty fields, text as is, blobs as 0x prefixed hex,
+// timestamps in RFC 3339 format, other scalars in their string form and
+// collections, tuples and UDTs as JSON.
+func ExportCSV(iter *Iterx, w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	columns := iter.Columns()
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.Name
+	}
+	if err := cw.Write(record); err != nil {
+		iter.Close()
+		return err
+	}
+
+	for {
+		values, ok := iter.scanRowValues()
+		if !ok {
+			break
+		}
+		for i, v := range values {
+			s, err := csvValue(v)
+			if err != nil {
+				iter.Close()
+				return fmt.Errorf("column %q: %s", columns[i].Name, err)
+			}
+			record[i] = s
+		}
+		if err := cw.Write(record); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvValue renders a value returned by scanRowValues as CSV field.
+func csvValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return "0x" + hex.EncodeToString(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case bool, int, int8, int16, int32, int64, float32, float64, time.Duration, fmt.Stringer:
+		return fmt.Sprint(v), nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestCSVValue(t *testing.T) {
+	uuid := gocql.UUID{0x1}
+
+	table := []struct {
+		Name string
+		V    interface{}
+		S    string
+	}{
+		{"null", nil, ""},
+		{"text", "foo, bar", "foo, bar"},
+		{"blob", []byte{0xca, 0xfe}, "0xcafe"},
+		{"timestamp", time.Date(2020, 2, 3, 4, 5, 6, 7000000, time.UTC), "2020-02-03T04:05:06.007Z"},
+		{"boolean", true, "true"},
+		{"int", 42, "42"},
+		{"double", 1.5, "1.5"},
+		{"time", time.Hour + time.Second, "1h0m1s"},
+		{"uuid", uuid, uuid.String()},
+		{"varint", big.NewInt(123), "123"},
+		{"list", []string{"a", "b"}, `["a","b"]`},
+		{"map", map[string]int{"x": 1}, `{"x":1}`},
+	}
+
+	for _, test := range table {
+		s, err := csvValue(test.V)
+		if err != nil {
+			t.Fatal(test.Name, err)
+		}
+		if s != test.S {
+			t.Error(test.Name, "expected", test.S, "got", s)
+		}
+	}
+}
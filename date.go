@@ -0,0 +1,68 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// Date represents a CQL date, the number of days since the Unix epoch. Unlike
+// time.Time, which gocql converts through nanoseconds, it covers the whole
+// range of the CQL date type.
+//
+// CQL time (nanoseconds since midnight) maps to time.Duration and does not
+// need a dedicated type.
+type Date int32
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay)
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+// Time returns midnight UTC of the date.
+func (d Date) Time() time.Time {
+	return time.Unix(int64(d)*secondsPerDay, 0).UTC()
+}
+
+// String returns the date formatted as "2006-01-02".
+func (d Date) String() string {
+	return d.Time().Format("2006-01-02")
+}
+
+// MarshalCQL implements gocql.Marshaler.
+func (d Date) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if info.Type() != gocql.TypeDate {
+		return nil, fmt.Errorf("can not marshal %T into %s", d, info)
+	}
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(int64(d)+dateOrigin))
+	return b, nil
+}
+
+// UnmarshalCQL implements gocql.Unmarshaler.
+func (d *Date) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if info.Type() != gocql.TypeDate {
+		return fmt.Errorf("can not unmarshal %s into %T", info, d)
+	}
+	switch len(data) {
+	case 0:
+		*d = 0
+	case 4:
+		*d = Date(int64(binary.BigEndian.Uint32(data)) - dateOrigin)
+	default:
+		return fmt.Errorf("can not unmarshal %s into %T: invalid length %d", info, d, len(data))
+	}
+	return nil
+}
+
+// dateOrigin is the encoded value of the Unix epoch.
+const dateOrigin = 1 << 31
@@ -0,0 +1,89 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestDate(t *testing.T) {
+	info := gocql.NewNativeType(4, gocql.TypeDate, "")
+
+	table := []struct {
+		Name string
+		D    Date
+		S    string
+		Data []byte
+	}{
+		{
+			Name: "epoch",
+			D:    0,
+			S:    "1970-01-01",
+			Data: []byte{0x80, 0, 0, 0},
+		},
+		{
+			Name: "before epoch",
+			D:    -1,
+			S:    "1969-12-31",
+			Data: []byte{0x7f, 0xff, 0xff, 0xff},
+		},
+		{
+			Name: "date",
+			D:    DateOf(time.Date(2020, 2, 3, 23, 59, 0, 0, time.FixedZone("", -3600))),
+			S:    "2020-02-03",
+			Data: []byte{0x80, 0, 0x47, 0x77},
+		},
+		{
+			Name: "min",
+			D:    math.MinInt32,
+			S:    "-5877641-06-23",
+			Data: []byte{0, 0, 0, 0},
+		},
+		{
+			Name: "max",
+			D:    math.MaxInt32,
+			S:    "5881580-07-11",
+			Data: []byte{0xff, 0xff, 0xff, 0xff},
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			if s := test.D.String(); s != test.S {
+				t.Error("expected", test.S, "got", s)
+			}
+			if d := DateOf(test.D.Time()); d != test.D {
+				t.Error("expected", test.D, "got", d)
+			}
+
+			data, err := gocql.Marshal(info, test.D)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != string(test.Data) {
+				t.Errorf("expected %x got %x", test.Data, data)
+			}
+
+			var d Date
+			if err := gocql.Unmarshal(info, data, &d); err != nil {
+				t.Fatal(err)
+			}
+			if d != test.D {
+				t.Error("expected", test.D, "got", d)
+			}
+		})
+	}
+
+	t.Run("invalid type", func(t *testing.T) {
+		if _, err := gocql.Marshal(gocql.NewNativeType(4, gocql.TypeTimestamp, ""), Date(0)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
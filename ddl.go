@@ -0,0 +1,144 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+)
+
+// StructDDL returns a CREATE TABLE statement for table with a column for
+// every field of model, a struct or a struct pointer, mapped by the
+// DefaultMapper. CQL types are inferred from Go types i.e. string is text,
+// int is int, []byte is blob, time.Time is timestamp, []T is list<T> and
+// map[K]V is map<K,V>. The inferred type can be overridden with a cql tag:
+//
+//     Tags map[string][]string `cql:"map<text, frozen<set<text>>>"`
+//
+// Elements of pk are written verbatim to the PRIMARY KEY clause so
+// a composite partition key can be given as "(a,b)". StructDDL is meant for
+// test setup, production schema should be written by hand.
+func StructDDL(table string, model interface{}, pk []string) (string, error) {
+	t := reflectx.Deref(reflect.TypeOf(model))
+	if t.Kind() != reflect.Struct {
+		return "", fmt.Errorf("expected a struct but got %T", model)
+	}
+
+	cql := bytes.Buffer{}
+	cql.WriteString("CREATE TABLE ")
+	cql.WriteString(table)
+	cql.WriteString(" (")
+
+	for _, fi := range defaultMapper().TypeMap(t).Index {
+		if fi.Embedded || strings.Contains(fi.Path, ".") {
+			continue
+		}
+		if _, ok := fi.Options["extra"]; ok {
+			continue
+		}
+
+		typ := fi.Field.Tag.Get("cql")
+		if typ == "" {
+			var err error
+			if typ, err = cqlType(fi.Field.Type); err != nil {
+				return "", fmt.Errorf("field %s: %s", fi.Field.Name, err)
+			}
+		}
+		cql.WriteString(fi.Name)
+		cql.WriteByte(' ')
+		cql.WriteString(typ)
+		cql.WriteString(", ")
+	}
+
+	cql.WriteString("PRIMARY KEY (")
+	cql.WriteString(strings.Join(pk, ","))
+	cql.WriteString("))")
+
+	return cql.String(), nil
+}
+
+var (
+	durationType = reflect.TypeOf(gocql.Duration{})
+	ipType       = reflect.TypeOf(net.IP{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+)
+
+// cqlType returns CQL type for Go type t.
+func cqlType(t reflect.Type) (string, error) {
+	t = reflectx.Deref(t)
+
+	switch t {
+	case timeType:
+		return "timestamp", nil
+	case durationType:
+		return "duration", nil
+	case uuidType:
+		return "uuid", nil
+	case ipType:
+		return "inet", nil
+	case bigIntType:
+		return "varint", nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "text", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int8:
+		return "tinyint", nil
+	case reflect.Int16:
+		return "smallint", nil
+	case reflect.Int, reflect.Int32:
+		return "int", nil
+	case reflect.Int64:
+		return "bigint", nil
+	case reflect.Float32:
+		return "float", nil
+	case reflect.Float64:
+		return "double", nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "blob", nil
+		}
+		elem, err := cqlElemType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "list<" + elem + ">", nil
+	case reflect.Map:
+		key, err := cqlElemType(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := cqlElemType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "map<" + key + ", " + elem + ">", nil
+	}
+
+	return "", fmt.Errorf("cannot infer CQL type of %s use cql tag", t)
+}
+
+// cqlElemType returns CQL type for collection element of Go type t, nested
+// collections are frozen.
+func cqlElemType(t reflect.Type) (string, error) {
+	typ, err := cqlType(t)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(typ, "list<") || strings.HasPrefix(typ, "map<") {
+		typ = "frozen<" + typ + ">"
+	}
+	return typ, nil
+}
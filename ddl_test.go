@@ -0,0 +1,91 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStructDDL(t *testing.T) {
+	type Embedded struct {
+		Note string
+	}
+
+	type Model struct {
+		Embedded
+		ID       gocql.UUID
+		Name     string
+		Age      int
+		Score    int64
+		Small    int16
+		Tiny     int8
+		Ratio    float64
+		Active   bool
+		Data     []byte
+		Created  time.Time
+		Updated  *time.Time
+		IP       net.IP
+		Big      *big.Int
+		Tags     []string
+		Nested   [][]int
+		Props    map[string]int
+		Custom   []string `cql:"set<text>"`
+		Renamed  string   `db:"other"`
+		Ignored  string   `db:"-"`
+		internal string
+	}
+
+	stmt, err := StructDDL("ks.model", &Model{}, []string{"(id,name)", "age"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "CREATE TABLE ks.model (" +
+		"id uuid, " +
+		"name text, " +
+		"age int, " +
+		"score bigint, " +
+		"small smallint, " +
+		"tiny tinyint, " +
+		"ratio double, " +
+		"active boolean, " +
+		"data blob, " +
+		"created timestamp, " +
+		"updated timestamp, " +
+		"ip inet, " +
+		"big varint, " +
+		"tags list<text>, " +
+		"nested list<frozen<list<int>>>, " +
+		"props map<text, int>, " +
+		"custom set<text>, " +
+		"other text, " +
+		"note text, " +
+		"PRIMARY KEY ((id,name),age))"
+	if diff := cmp.Diff(expected, stmt); diff != "" {
+		t.Fatal(diff)
+	}
+
+	t.Run("unsupported type", func(t *testing.T) {
+		type Unsupported struct {
+			ID int
+			C  chan int
+		}
+		if _, err := StructDDL("ks.model", Unsupported{}, []string{"id"}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		if _, err := StructDDL("ks.model", 1, []string{"id"}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -7,4 +7,8 @@
 // structs, use named query parameters (:identifier) and scan the query results
 // into structs and slices. It comes with a fluent and flexible CQL query
 // builder and a database migrations module.
+//
+// Struct fields are bound and scanned by gocql, so fields can be of any type
+// gocql supports for the column type i.e. net.IP for inet columns holding
+// IPv4 or IPv6 addresses.
 package gocqlx
@@ -0,0 +1,45 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// EpochMillis is a time.Time stored as milliseconds since the Unix epoch in a
+// bigint column, it allows to use time.Time in models of schemas that do not
+// use the CQL timestamp type i.e. EpochMillis(t). Zero time is stored as null
+// and null is scanned as zero time.
+type EpochMillis time.Time
+
+// Time returns the time as time.Time.
+func (e EpochMillis) Time() time.Time {
+	return time.Time(e)
+}
+
+// MarshalCQL implements gocql.Marshaler.
+func (e EpochMillis) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	t := time.Time(e)
+	if t.IsZero() {
+		return nil, nil
+	}
+	return gocql.Marshal(info, t.Unix()*1e3+int64(t.Nanosecond())/1e6)
+}
+
+// UnmarshalCQL implements gocql.Unmarshaler.
+func (e *EpochMillis) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if len(data) == 0 {
+		*e = EpochMillis{}
+		return nil
+	}
+	var ms int64
+	if err := gocql.Unmarshal(info, data, &ms); err != nil {
+		return err
+	}
+	*e = EpochMillis(time.Unix(ms/1e3, ms%1e3*1e6).UTC())
+	return nil
+}
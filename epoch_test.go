@@ -0,0 +1,80 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestEpochMillis(t *testing.T) {
+	info := gocql.NewNativeType(4, gocql.TypeBigInt, "")
+
+	table := []struct {
+		Name string
+		T    time.Time
+		MS   int64
+	}{
+		{
+			Name: "epoch",
+			T:    time.Unix(0, 0).UTC(),
+			MS:   0,
+		},
+		{
+			Name: "millis",
+			T:    time.Date(2020, 2, 3, 10, 20, 30, 123000000, time.UTC),
+			MS:   1580725230123,
+		},
+		{
+			Name: "before epoch",
+			T:    time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC),
+			MS:   -500,
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			data, err := gocql.Marshal(info, EpochMillis(test.T))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ms int64
+			if err := gocql.Unmarshal(info, data, &ms); err != nil {
+				t.Fatal(err)
+			}
+			if ms != test.MS {
+				t.Error("expected", test.MS, "got", ms)
+			}
+
+			var e EpochMillis
+			if err := gocql.Unmarshal(info, data, &e); err != nil {
+				t.Fatal(err)
+			}
+			if !e.Time().Equal(test.T) {
+				t.Error("expected", test.T, "got", e.Time())
+			}
+		})
+	}
+
+	t.Run("zero", func(t *testing.T) {
+		data, err := gocql.Marshal(info, EpochMillis{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data != nil {
+			t.Fatal("expected null got", data)
+		}
+		e := EpochMillis(time.Now())
+		if err := gocql.Unmarshal(info, nil, &e); err != nil {
+			t.Fatal(err)
+		}
+		if !e.Time().IsZero() {
+			t.Fatal("expected zero time got", e.Time())
+		}
+	})
+}
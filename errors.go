@@ -0,0 +1,77 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrColumnCount matches ColumnCountError with errors.Is.
+	ErrColumnCount = errors.New("unexpected number of columns")
+	// ErrMissingDestination matches MissingDestinationError with errors.Is.
+	ErrMissingDestination = errors.New("missing destination")
+	// ErrMissingRequired matches MissingRequiredError with errors.Is.
+	ErrMissingRequired = errors.New("missing required column")
+	// ErrQueryReleased is returned when a query is bound or executed after
+	// Queryx.Release.
+	ErrQueryReleased = errors.New("query is released")
+)
+
+// ColumnCountError is reported when a result with more than one column is
+// scanned into a scannable (non struct) type.
+type ColumnCountError struct {
+	// Kind is the kind of the destination type.
+	Kind reflect.Kind
+	// Columns is the number of columns in the result.
+	Columns int
+}
+
+func (e *ColumnCountError) Error() string {
+	return fmt.Sprintf("expected 1 column in result while scanning scannable type %s but got %d", e.Kind, e.Columns)
+}
+
+// Is reports whether target is ErrColumnCount.
+func (e *ColumnCountError) Is(target error) bool {
+	return target == ErrColumnCount
+}
+
+// MissingDestinationError is reported when a column or a UDT field cannot be
+// mapped to any field of the destination struct.
+type MissingDestinationError struct {
+	// Column is the name of the column or UDT field.
+	Column string
+	// Type is the type of the destination.
+	Type reflect.Type
+}
+
+func (e *MissingDestinationError) Error() string {
+	return fmt.Sprintf("missing destination name %q in %s", e.Column, e.Type)
+}
+
+// Is reports whether target is ErrMissingDestination.
+func (e *MissingDestinationError) Is(target error) bool {
+	return target == ErrMissingDestination
+}
+
+// MissingRequiredError is reported when a field tagged with the required
+// option i.e. `db:"name,required"` is not mapped to any column of the result.
+type MissingRequiredError struct {
+	// Column is the name of the required column.
+	Column string
+	// Type is the type of the destination.
+	Type reflect.Type
+}
+
+func (e *MissingRequiredError) Error() string {
+	return fmt.Sprintf("missing required column %q in result for %s", e.Column, e.Type)
+}
+
+// Is reports whether target is ErrMissingRequired.
+func (e *MissingRequiredError) Is(target error) bool {
+	return target == ErrMissingRequired
+}
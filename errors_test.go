@@ -0,0 +1,58 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("column count", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", &ColumnCountError{Kind: reflect.Int, Columns: 2})
+		if msg := err.Error(); msg != "wrapped: expected 1 column in result while scanning scannable type int but got 2" {
+			t.Fatal("unexpected message", msg)
+		}
+		var e *ColumnCountError
+		if !errors.As(err, &e) || e.Columns != 2 {
+			t.Fatal("expected ColumnCountError")
+		}
+		if !errors.Is(err, ErrColumnCount) || errors.Is(err, ErrMissingDestination) {
+			t.Fatal("unexpected Is result")
+		}
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		type T struct{}
+		err := fmt.Errorf("wrapped: %w", &MissingDestinationError{Column: "name", Type: reflect.TypeOf(&T{})})
+		if msg := err.Error(); msg != `wrapped: missing destination name "name" in *gocqlx.T` {
+			t.Fatal("unexpected message", msg)
+		}
+		var e *MissingDestinationError
+		if !errors.As(err, &e) || e.Column != "name" {
+			t.Fatal("expected MissingDestinationError")
+		}
+		if !errors.Is(err, ErrMissingDestination) || errors.Is(err, ErrColumnCount) {
+			t.Fatal("unexpected Is result")
+		}
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		type T struct{}
+		err := fmt.Errorf("wrapped: %w", &MissingRequiredError{Column: "email", Type: reflect.TypeOf(&T{})})
+		if msg := err.Error(); msg != `wrapped: missing required column "email" in result for *gocqlx.T` {
+			t.Fatal("unexpected message", msg)
+		}
+		var e *MissingRequiredError
+		if !errors.As(err, &e) || e.Column != "email" {
+			t.Fatal("expected MissingRequiredError")
+		}
+		if !errors.Is(err, ErrMissingRequired) || errors.Is(err, ErrMissingDestination) {
+			t.Fatal("unexpected Is result")
+		}
+	})
+}
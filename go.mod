@@ -6,6 +6,7 @@ require (
 	github.com/golang/snappy v0.0.1 // indirect
 	github.com/google/go-cmp v0.2.0
 	github.com/scylladb/go-reflectx v1.0.1
+	github.com/shopspring/decimal v1.2.0
 	gopkg.in/inf.v0 v0.9.1
 )
 
@@ -0,0 +1,104 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// In expands slice values bound to IN markers into a list of markers, one for
+// each element, and flattens the values. It is meant to be used with
+// positional binding i.e. Queryx.Bind. Both `IN ?` and `IN (?)` forms are
+// supported, values bound to other markers are left untouched.
+//
+// Example:
+//     stmt, args, err := gocqlx.In(`SELECT * FROM t WHERE id IN ?`, []int{1, 2, 3})
+//     // stmt is SELECT * FROM t WHERE id IN (?,?,?), args is [1 2 3]
+func In(stmt string, args ...interface{}) (string, []interface{}, error) {
+	var (
+		buf     bytes.Buffer
+		flat    = make([]interface{}, 0, len(args))
+		n       int
+		inQuote bool
+	)
+	for i := 0; i < len(stmt); i++ {
+		c := stmt[i]
+		if c == '\'' {
+			inQuote = !inQuote
+		}
+		if c != '?' || inQuote {
+			buf.WriteByte(c)
+			continue
+		}
+		if n >= len(args) {
+			return "", nil, fmt.Errorf("expected %d bind values, got %d", placeholderCount(stmt), len(args))
+		}
+		arg := args[n]
+		n++
+
+		paren, ok := inMarker(stmt[:i])
+		v := reflect.ValueOf(arg)
+		if !ok || !isInSlice(v) {
+			buf.WriteByte(c)
+			flat = append(flat, arg)
+			continue
+		}
+		if v.Len() == 0 {
+			return "", nil, errors.New("empty slice passed to IN")
+		}
+
+		if !paren {
+			buf.WriteByte('(')
+		}
+		for j := 0; j < v.Len(); j++ {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('?')
+			flat = append(flat, v.Index(j).Interface())
+		}
+		if !paren {
+			buf.WriteByte(')')
+		}
+	}
+	if n != len(args) {
+		return "", nil, fmt.Errorf("expected %d bind values, got %d", n, len(args))
+	}
+
+	return buf.String(), flat, nil
+}
+
+// inMarker checks if a marker placed after prefix is an IN marker, paren is
+// true if the marker is already enclosed in parenthesis.
+func inMarker(prefix string) (paren, ok bool) {
+	prefix = strings.TrimRight(prefix, " \t\n")
+	if strings.HasSuffix(prefix, "(") {
+		paren = true
+		prefix = strings.TrimRight(prefix[:len(prefix)-1], " \t\n")
+	}
+	if len(prefix) < 2 || !strings.EqualFold(prefix[len(prefix)-2:], "IN") {
+		return false, false
+	}
+	if len(prefix) > 2 {
+		if b := prefix[len(prefix)-3]; allowedBindRune(b) || b == '_' {
+			return false, false
+		}
+	}
+	return paren, true
+}
+
+func isInSlice(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return true
+	}
+	return false
+}
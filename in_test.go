@@ -0,0 +1,87 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIn(t *testing.T) {
+	table := []struct {
+		Name   string
+		Stmt   string
+		Args   []interface{}
+		Result string
+		Values []interface{}
+	}{
+		{
+			Name:   "in",
+			Stmt:   "SELECT * FROM t WHERE a=? AND id IN ?",
+			Args:   []interface{}{"a", []int{1, 2, 3}},
+			Result: "SELECT * FROM t WHERE a=? AND id IN (?,?,?)",
+			Values: []interface{}{"a", 1, 2, 3},
+		},
+		{
+			Name:   "in parenthesis",
+			Stmt:   "SELECT * FROM t WHERE id in (?) AND a=?",
+			Args:   []interface{}{[3]string{"x", "y", "z"}, "a"},
+			Result: "SELECT * FROM t WHERE id in (?,?,?) AND a=?",
+			Values: []interface{}{"x", "y", "z", "a"},
+		},
+		{
+			Name:   "not in",
+			Stmt:   "UPDATE t SET list=? WHERE id=? AND b=?",
+			Args:   []interface{}{[]int{1, 2, 3}, 1, []byte("blob")},
+			Result: "UPDATE t SET list=? WHERE id=? AND b=?",
+			Values: []interface{}{[]int{1, 2, 3}, 1, []byte("blob")},
+		},
+		{
+			Name:   "column ending with in",
+			Stmt:   "SELECT * FROM t WHERE login=? AND tag_in=?",
+			Args:   []interface{}{"a", []int{1}},
+			Result: "SELECT * FROM t WHERE login=? AND tag_in=?",
+			Values: []interface{}{"a", []int{1}},
+		},
+		{
+			Name:   "quoted",
+			Stmt:   "SELECT * FROM t WHERE a='IN ?' AND id IN ?",
+			Args:   []interface{}{[]int{1, 2}},
+			Result: "SELECT * FROM t WHERE a='IN ?' AND id IN (?,?)",
+			Values: []interface{}{1, 2},
+		},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			stmt, values, err := In(test.Stmt, test.Args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Result, stmt); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(test.Values, values); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		if _, _, err := In("SELECT * FROM t WHERE id IN ?", []int{}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("count mismatch", func(t *testing.T) {
+		if _, _, err := In("SELECT * FROM t WHERE id IN ?"); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, _, err := In("SELECT * FROM t WHERE id IN ?", []int{1}, 2); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/qb"
+)
+
+// Interpolate returns stmt with the '?' placeholders replaced with values of
+// names bound from arg, arg can be a struct or a map. Values are rendered as
+// CQL literals, strings are quoted and escaped.
+//
+// Interpolate is meant for logging and debugging only, the result must not
+// be executed, use binding instead.
+func Interpolate(stmt string, names []string, arg interface{}) (string, error) {
+	var (
+		values []interface{}
+		err    error
+	)
+	if m, ok := asMap(arg); ok {
+		values, err = bindMapArgs(names, m)
+	} else {
+		values, err = bindStructArgs(names, arg, nil, defaultMapper())
+	}
+	if err != nil {
+		return "", fmt.Errorf("bind error: %s", err)
+	}
+
+	var (
+		buf     bytes.Buffer
+		n       int
+		inQuote bool
+	)
+	for i := 0; i < len(stmt); i++ {
+		c := stmt[i]
+		switch {
+		case c == '\'':
+			inQuote = !inQuote
+			buf.WriteByte(c)
+		case c == '?' && !inQuote:
+			if n >= len(values) {
+				return "", fmt.Errorf("expected %d bind values, got %d", placeholderCount(stmt), len(values))
+			}
+			buf.WriteString(qb.Literal(values[n]))
+			n++
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	if n != len(values) {
+		return "", fmt.Errorf("expected %d bind values, got %d", n, len(values))
+	}
+
+	return buf.String(), nil
+}
+
+func asMap(arg interface{}) (map[string]interface{}, bool) {
+	if m, ok := arg.(map[string]interface{}); ok {
+		return m, true
+	}
+	v := reflect.ValueOf(arg)
+	if v.Kind() == reflect.Map && v.Type().ConvertibleTo(mapType) {
+		return v.Convert(mapType).Interface().(map[string]interface{}), true
+	}
+	return nil, false
+}
+
+var mapType = reflect.TypeOf(map[string]interface{}(nil))
+
+// placeholderCount returns number of '?' placeholders outside of string
+// literals.
+func placeholderCount(stmt string) int {
+	n := 0
+	inQuote := false
+	for i := 0; i < len(stmt); i++ {
+		switch stmt[i] {
+		case '\'':
+			inQuote = !inQuote
+		case '?':
+			if !inQuote {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(gocql.UUID{})
+)
@@ -0,0 +1,104 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/qb"
+)
+
+func TestInterpolate(t *testing.T) {
+	stmt, names := qb.Insert("person").Columns("first_name", "age", "email", "address", "created", "blob").ToCql()
+
+	t.Run("struct", func(t *testing.T) {
+		v := struct {
+			FirstName string
+			Age       int
+			Email     []string
+			Address   *string
+			Created   time.Time
+			Blob      []byte
+		}{
+			FirstName: "Patricia's",
+			Age:       30,
+			Email:     []string{"patricia@example.com", "p@example.com"},
+			Created:   time.Date(2005, 05, 05, 0, 0, 0, 0, time.UTC),
+			Blob:      []byte{0xca, 0xfe},
+		}
+		s, err := Interpolate(stmt, names, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		golden := "INSERT INTO person (first_name,age,email,address,created,blob) VALUES ('Patricia''s',30,['patricia@example.com','p@example.com'],null,'2005-05-05T00:00:00.000Z',0xcafe) "
+		if diff := cmp.Diff(golden, s); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		stmt, names := qb.Update("person").Set("tags").Where(qb.Eq("first_name")).ToCql()
+		s, err := Interpolate(stmt, names, qb.M{
+			"first_name": "Patricia",
+			"tags":       map[string]int{"b": 2, "a": 1},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		golden := "UPDATE person SET tags={'a':1,'b':2} WHERE first_name='Patricia' "
+		if diff := cmp.Diff(golden, s); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("quoted placeholder", func(t *testing.T) {
+		s, err := Interpolate("SELECT '?' FROM person WHERE first_name=?", []string{"first_name"}, qb.M{"first_name": "Patricia"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		golden := "SELECT '?' FROM person WHERE first_name='Patricia'"
+		if diff := cmp.Diff(golden, s); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		if _, err := Interpolate(stmt, names, qb.M{"first_name": "Patricia"}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		if _, err := Interpolate(stmt, names, nil); err == nil || !strings.HasPrefix(err.Error(), `bind error: could not find name "first_name"`) {
+			t.Fatal("expected bind error got", err)
+		}
+		var p *struct{ FirstName string }
+		if _, err := Interpolate(stmt, names, p); err == nil || !strings.HasPrefix(err.Error(), `bind error: could not find name "first_name"`) {
+			t.Fatal("expected bind error got", err)
+		}
+		s, err := Interpolate("SELECT * FROM person", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != "SELECT * FROM person" {
+			t.Fatal("unexpected statement", s)
+		}
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		if _, err := Interpolate(stmt, names, 1); err == nil || err.Error() != "bind error: expected a struct or a struct pointer, got int" {
+			t.Fatal("expected bind error got", err)
+		}
+	})
+
+	t.Run("count mismatch", func(t *testing.T) {
+		if _, err := Interpolate("SELECT * FROM person WHERE first_name=?", []string{"first_name", "age"}, qb.M{"first_name": "Patricia", "age": 30}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -5,6 +5,7 @@
 package gocqlx
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -17,6 +18,16 @@ import (
 // missing fields for all queries. See Unsafe below for more information.
 var DefaultUnsafe bool
 
+// DefaultStructOnly enables the behavior of forcing the iterator to treat
+// single-argument structs as non-scannable for all queries. See StructOnly
+// below for more information. Unlike StructOnly it does not report an error
+// when scanning into a non-struct type i.e. int or []string.
+var DefaultStructOnly bool
+
+// ErrTooManyRows is returned by Select and SelectAppend if the result has more
+// rows than allowed by MaxRows.
+var ErrTooManyRows = errors.New("too many rows")
+
 // Iterx is a wrapper around gocql.Iter which adds struct scanning capabilities.
 type Iterx struct {
 	*gocql.Iter
@@ -24,20 +35,58 @@ type Iterx struct {
 
 	unsafe     bool
 	structOnly bool
+	nested     bool
+	ignoreCase bool
+	nullPolicy NullPolicy
+	filter     FieldFilter
+	maxRows    int
 	started    bool
 	err        error
+	dropped    []string
+
+	// Set from DefaultStructOnly, unlike structOnly it does not reject
+	// scanning into non-struct types.
+	defaultStructOnly bool
+
+	// Context checked on page boundaries by SelectContext.
+	ctx context.Context
+
+	// Query stats captured on Close.
+	query    *gocql.Query
+	attempts int
+	latency  int64
 
 	// Cache memory for a rows during iteration in StructScan.
-	fields [][]int
-	values []interface{}
+	fields   [][]int
+	values   []interface{}
+	scanners []*columnScanner
+	dests    []interface{}
+
+	// Raw row values for RowUnmarshaler.
+	raw       []rawColumn
+	rawValues [][]byte
+
+	// Overflow map field and unmapped columns collected into it.
+	extra       []int
+	extraValues []rowValue
+
+	// First row of a conditional batch result scanned by Batch.ExecCAS.
+	casFirst *casRow
+
+	// Rows read by Buffered.
+	buffered bool
+	rows     [][][]byte
+	pos      int
 }
 
 // Iter creates a new Iterx from gocql.Query using a default mapper.
 func Iter(q *gocql.Query) *Iterx {
 	return &Iterx{
-		Iter:   q.Iter(),
-		Mapper: DefaultMapper,
-		unsafe: DefaultUnsafe,
+		Iter:              q.Iter(),
+		Mapper:            defaultMapper(),
+		query:             q,
+		unsafe:            DefaultUnsafe,
+		defaultStructOnly: DefaultStructOnly,
 	}
 }
 
@@ -57,6 +106,59 @@ func (iter *Iterx) StructOnly() *Iterx {
 	return iter
 }
 
+// AllowScannable reverts StructOnly, a single-argument struct that implements
+// gocql.UDTUnmarshaler or gocql.Unmarshaler is scanned as a single column value.
+// It allows to opt out of DefaultStructOnly for a single iterator.
+func (iter *Iterx) AllowScannable() *Iterx {
+	iter.structOnly = false
+	iter.defaultStructOnly = false
+	return iter
+}
+
+// NestedColumns enables mapping of `_` separated column names to fields of
+// nested structs, i.e. address_city and address_zip columns are scanned into
+// Address.City and Address.Zip fields. This is useful for flattened
+// denormalized tables. Columns matching a field name directly take
+// precedence.
+func (iter *Iterx) NestedColumns() *Iterx {
+	iter.nested = true
+	return iter
+}
+
+// IgnoreCase enables case-insensitive matching of column names to fields,
+// underscores are ignored as well, i.e. FirstName, first_name and FIRST_NAME
+// columns are all scanned into the FirstName field. Names that match a field
+// directly take precedence, names matching more than one field are left
+// unmapped.
+func (iter *Iterx) IgnoreCase() *Iterx {
+	iter.ignoreCase = true
+	return iter
+}
+
+// NullPolicy sets how StructScan handles NULL values scanned into fields that
+// cannot represent NULL, see NullPolicy type.
+func (iter *Iterx) NullPolicy(p NullPolicy) *Iterx {
+	iter.nullPolicy = p
+	return iter
+}
+
+// FieldFilter sets a filter deciding which struct fields are scanned, fields
+// rejected by the filter are treated as if they were not there, see
+// FieldFilter type.
+func (iter *Iterx) FieldFilter(filter FieldFilter) *Iterx {
+	iter.filter = filter
+	return iter
+}
+
+// MaxRows limits the number of rows Select and SelectAppend collect to n, if
+// the result has more rows the iteration stops and ErrTooManyRows is
+// returned, the first n rows are kept in dest. This is a safety valve against
+// accidentally unbounded queries. Zero means no limit.
+func (iter *Iterx) MaxRows(n int) *Iterx {
+	iter.maxRows = n
+	return iter
+}
+
 // Get scans first row into a destination and closes the iterator.
 //
 // If the destination type is a struct pointer, then StructScan will be
@@ -69,6 +171,10 @@ func (iter *Iterx) StructOnly() *Iterx {
 // gocql.UDTUnmarshaler as an ordinary struct you should call
 // StructOnly().Get(dest) instead.
 //
+// Note that a slice destination i.e. *[]string is scanned from a single
+// collection (list or set) column of the first row, to collect a column
+// across all the rows use Select.
+//
 // If no rows were selected, ErrNotFound is returned.
 func (iter *Iterx) Get(dest interface{}) error {
 	iter.scanAny(dest)
@@ -77,12 +183,271 @@ func (iter *Iterx) Get(dest interface{}) error {
 	return iter.checkErrAndNotFound()
 }
 
+// GetCAS scans the result of a conditional (lightweight transaction)
+// statement and closes the iterator. It returns true if the statement was
+// applied. If the statement was not applied the existing row is scanned into
+// dest, which must be a struct pointer, otherwise dest is left untouched.
+func (iter *Iterx) GetCAS(dest interface{}) (applied bool, err error) {
+	applied = iter.scanCAS(dest)
+	iter.Close()
+
+	if err := iter.checkErrAndNotFound(); err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// SelectCAS scans the result of a conditional batch statement and closes the
+// iterator. It returns true if the batch was applied. If the batch was not
+// applied the existing rows, one for every conditional statement, are
+// selected into dest, which must be a pointer to slice of structs, otherwise
+// dest is left untouched.
+func (iter *Iterx) SelectCAS(dest interface{}) (applied bool, err error) {
+	applied = iter.scanAllCAS(dest)
+	iter.Close()
+
+	if err := iter.checkErrAndNotFound(); err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// SelectCASResults scans the result of a conditional batch statement into
+// dest and closes the iterator. Unlike SelectCAS all the result rows are
+// selected, dest must be a pointer to slice of structs with a bool field
+// mapped to "applied" name i.e. Applied, the [applied] column of every row is
+// scanned into that field. It returns true if the batch was applied.
+func (iter *Iterx) SelectCASResults(dest interface{}) (applied bool, err error) {
+	applied = iter.scanAllCASResults(dest)
+	iter.Close()
+
+	if err := iter.checkErrAndNotFound(); err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+func (iter *Iterx) scanCAS(dest interface{}) bool {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr {
+		iter.err = fmt.Errorf("expected a pointer but got %T", dest)
+		return false
+	}
+	if value.IsNil() {
+		iter.err = errors.New("expected a pointer but got nil")
+		return false
+	}
+
+	base := reflectx.Deref(value.Type())
+	columns, fields, ok := iter.casColumns(base, dest)
+	if !ok {
+		return false
+	}
+
+	// scan into a copy so that dest is not modified if applied
+	vp, applied, ok := iter.scanCASRow(base, columns, fields)
+	if !ok {
+		return false
+	}
+	if !applied {
+		reflect.Indirect(value).Set(vp.Elem())
+	}
+	return applied
+}
+
+func (iter *Iterx) scanAllCAS(dest interface{}) bool {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr {
+		iter.err = fmt.Errorf("expected a pointer but got %T", dest)
+		return false
+	}
+	if value.IsNil() {
+		iter.err = errors.New("expected a pointer but got nil")
+		return false
+	}
+
+	slice, err := baseType(value.Type(), reflect.Slice)
+	if err != nil {
+		iter.err = err
+		return false
+	}
+	isPtr := slice.Elem().Kind() == reflect.Ptr
+	base := reflectx.Deref(slice.Elem())
+
+	columns, fields, ok := iter.casColumns(base, dest)
+	if !ok {
+		return false
+	}
+
+	var (
+		applied = true
+		v       = reflect.MakeSlice(slice, 0, iter.NumRows())
+	)
+	for {
+		vp, rowApplied, ok := iter.scanCASRow(base, columns, fields)
+		if !ok {
+			break
+		}
+		if rowApplied {
+			continue
+		}
+		applied = false
+		if isPtr {
+			v = reflect.Append(v, vp)
+		} else {
+			v = reflect.Append(v, reflect.Indirect(vp))
+		}
+	}
+	if !applied && iter.err == nil {
+		reflect.Indirect(value).Set(v)
+	}
+	return applied
+}
+
+func (iter *Iterx) scanAllCASResults(dest interface{}) bool {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr {
+		iter.err = fmt.Errorf("expected a pointer but got %T", dest)
+		return false
+	}
+	if value.IsNil() {
+		iter.err = errors.New("expected a pointer but got nil")
+		return false
+	}
+
+	slice, err := baseType(value.Type(), reflect.Slice)
+	if err != nil {
+		iter.err = err
+		return false
+	}
+	isPtr := slice.Elem().Kind() == reflect.Ptr
+	base := reflectx.Deref(slice.Elem())
+
+	columns, fields, ok := iter.casColumns(base, dest)
+	if !ok {
+		return false
+	}
+	appliedField := iter.traversals(base, []string{"applied"})[0]
+	if len(appliedField) == 0 || base.FieldByIndex(appliedField).Type.Kind() != reflect.Bool {
+		iter.err = fmt.Errorf("missing applied bool field in %s", base)
+		return false
+	}
+
+	var (
+		applied = true
+		v       = reflect.MakeSlice(slice, 0, iter.NumRows())
+	)
+	for {
+		vp, rowApplied, ok := iter.scanCASRow(base, columns, fields)
+		if !ok {
+			break
+		}
+		reflectx.FieldByIndexes(vp.Elem(), appliedField).SetBool(rowApplied)
+		applied = applied && rowApplied
+		if isPtr {
+			v = reflect.Append(v, vp)
+		} else {
+			v = reflect.Append(v, reflect.Indirect(vp))
+		}
+	}
+	if iter.err == nil {
+		reflect.Indirect(value).Set(v)
+	}
+	return applied
+}
+
+// casColumns validates result of a conditional statement and returns
+// the columns following the [applied] column and their traversals in base.
+// Unless the iterator is unsafe the columns are checked for missing
+// destinations and required fields the same way as in StructScan.
+func (iter *Iterx) casColumns(base reflect.Type, dest interface{}) (columns []gocql.ColumnInfo, fields [][]int, ok bool) {
+	columns = iter.Iter.Columns()
+	if len(columns) == 0 || columns[0].Name != "[applied]" {
+		iter.err = errors.New("expected [applied] column in result of a conditional statement")
+		return nil, nil, false
+	}
+	columns = columns[1:]
+
+	fields = iter.traversals(base, columnNames(columns))
+	if !iter.unsafe {
+		if f, err := missingFields(fields); err != nil {
+			iter.err = &MissingDestinationError{Column: columns[f].Name, Type: reflect.TypeOf(dest)}
+			return nil, nil, false
+		}
+		// applied result and not existing row have no columns to check
+		if len(columns) > 0 {
+			if name, ok := missingRequired(iter.Mapper, base, fields); ok {
+				iter.err = &MissingRequiredError{Column: name, Type: reflect.TypeOf(dest)}
+				return nil, nil, false
+			}
+		}
+	}
+	return columns, fields, true
+}
+
+// scanCASRow scans a row of a conditional statement result into a new value
+// of base type.
+func (iter *Iterx) scanCASRow(base reflect.Type, columns []gocql.ColumnInfo, fields [][]int) (vp reflect.Value, applied, ok bool) {
+	vp = reflect.New(base)
+	values := make([]interface{}, len(columns))
+	if err := fieldsByTraversal(vp, fields, values, true); err != nil {
+		iter.err = err
+		return vp, false, false
+	}
+	scanners, dests := columnScanners(columns, base, fields)
+	for i, s := range scanners {
+		if s != nil {
+			s.dest = values[i]
+		}
+	}
+
+	if row := iter.casFirst; row != nil {
+		iter.casFirst = nil
+		for i, c := range columns {
+			if dests[i] == nil {
+				continue
+			}
+			if err := unmarshalMapValue(c.TypeInfo, row.values[c.Name], dests[i]); err != nil {
+				iter.err = err
+				return vp, false, false
+			}
+		}
+		return vp, row.applied, true
+	}
+
+	ok = iter.Scan(append([]interface{}{&applied}, dests...)...)
+	return vp, applied, ok
+}
+
+// casRow is a row of a conditional batch result scanned with MapScan.
+type casRow struct {
+	applied bool
+	values  map[string]interface{}
+}
+
+// unmarshalMapValue unmarshals a value scanned with MapScan into dest, raw
+// values are unmarshalled as is, other values are marshalled back first.
+func unmarshalMapValue(info gocql.TypeInfo, v, dest interface{}) error {
+	if r, ok := v.(rawColumn); ok {
+		return gocql.Unmarshal(info, r.data, dest)
+	}
+	b, err := gocql.Marshal(info, v)
+	if err != nil {
+		return err
+	}
+	return gocql.Unmarshal(info, b, dest)
+}
+
 // isScannable takes the reflect.Type and the actual dest value and returns
 // whether or not it's Scannable. t is scannable if:
 //   * ptr to t implements gocql.Unmarshaler or gocql.UDTUnmarshaler
 //   * it is not a struct
 //   * it has no exported fields
+// Types implementing RowUnmarshaler are never scannable.
 func (iter *Iterx) isScannable(t reflect.Type) bool {
+	if reflect.PtrTo(t).Implements(rowUnmarshalerInterface) {
+		return false
+	}
 	if ptr := reflect.PtrTo(t); ptr.Implements(unmarshallerInterface) || ptr.Implements(udtUnmarshallerInterface) {
 		return true
 	}
@@ -107,17 +472,17 @@ func (iter *Iterx) scanAny(dest interface{}) bool {
 	base := reflectx.Deref(value.Type())
 	scannable := iter.isScannable(base)
 
-	if iter.structOnly && scannable {
+	if (iter.structOnly || iter.defaultStructOnly) && scannable {
 		if base.Kind() == reflect.Struct {
 			scannable = false
-		} else {
+		} else if iter.structOnly {
 			iter.err = structOnlyError(base)
 			return false
 		}
 	}
 
 	if scannable && len(iter.Columns()) > 1 {
-		iter.err = fmt.Errorf("expected 1 column in result while scanning scannable type %s but got %d", base.Kind(), len(iter.Columns()))
+		iter.err = &ColumnCountError{Kind: base.Kind(), Columns: len(iter.Columns())}
 		return false
 	}
 
@@ -134,7 +499,8 @@ func (iter *Iterx) scanAny(dest interface{}) bool {
 // If the destination slice type is a struct, then StructScan will be used
 // on each row.
 // If the destination is some other type, then each row must only have one
-// column which can scan into that type.
+// column which can scan into that type, i.e. ids of all the rows can be
+// selected into *[]string.
 // This includes types that implement gocql.Unmarshaler and gocql.UDTUnmarshaler.
 //
 // If you'd like to treat a type that implements gocql.Unmarshaler or
@@ -143,13 +509,43 @@ func (iter *Iterx) scanAny(dest interface{}) bool {
 //
 // If no rows were selected, ErrNotFound is NOT returned.
 func (iter *Iterx) Select(dest interface{}) error {
-	iter.scanAll(dest)
+	iter.scanAll(dest, false)
 	iter.Close()
 
 	return iter.err
 }
 
-func (iter *Iterx) scanAll(dest interface{}) bool {
+// GetContext is like Get but it returns ctx.Err() if ctx is done before the
+// row is scanned.
+func (iter *Iterx) GetContext(ctx context.Context, dest interface{}) error {
+	if err := ctx.Err(); err != nil {
+		iter.err = err
+		iter.Close()
+		return err
+	}
+	return iter.Get(dest)
+}
+
+// SelectContext is like Select but it checks ctx before fetching the next
+// page, if ctx is done the iteration stops and ctx.Err() is returned. The
+// rows scanned so far are kept in dest. It allows to abort long scans
+// promptly.
+func (iter *Iterx) SelectContext(ctx context.Context, dest interface{}) error {
+	iter.ctx = ctx
+	return iter.Select(dest)
+}
+
+// SelectAppend is like Select but it appends the rows to the destination
+// slice preserving its contents, this is useful for accumulating results of
+// multiple queries.
+func (iter *Iterx) SelectAppend(dest interface{}) error {
+	iter.scanAll(dest, true)
+	iter.Close()
+
+	return iter.err
+}
+
+func (iter *Iterx) scanAll(dest interface{}, appendRows bool) bool {
 	value := reflect.ValueOf(dest)
 
 	// json.Unmarshal returns errors for these
@@ -172,10 +568,10 @@ func (iter *Iterx) scanAll(dest interface{}) bool {
 	base := reflectx.Deref(slice.Elem())
 	scannable := iter.isScannable(base)
 
-	if iter.structOnly && scannable {
+	if (iter.structOnly || iter.defaultStructOnly) && scannable {
 		if base.Kind() == reflect.Struct {
 			scannable = false
-		} else {
+		} else if iter.structOnly {
 			iter.err = structOnlyError(base)
 			return false
 		}
@@ -183,7 +579,7 @@ func (iter *Iterx) scanAll(dest interface{}) bool {
 
 	// if it's a base type make sure it only has 1 column;  if not return an error
 	if scannable && len(iter.Columns()) > 1 {
-		iter.err = fmt.Errorf("expected 1 column in result while scanning scannable type %s but got %d", base.Kind(), len(iter.Columns()))
+		iter.err = &ColumnCountError{Kind: base.Kind(), Columns: len(iter.Columns())}
 		return false
 	}
 
@@ -192,8 +588,20 @@ func (iter *Iterx) scanAll(dest interface{}) bool {
 		v     reflect.Value
 		vp    reflect.Value
 		ok    bool
+		rows  int
 	)
+	if appendRows {
+		v = reflect.Indirect(value)
+		alloc = true
+	}
 	for {
+		if iter.ctx != nil && iter.WillSwitchPage() {
+			if err := iter.ctx.Err(); err != nil {
+				iter.err = err
+				break
+			}
+		}
+
 		// create a new struct type (which returns PtrTo) and indirect it
 		vp = reflect.New(base)
 
@@ -206,6 +614,10 @@ func (iter *Iterx) scanAll(dest interface{}) bool {
 		if !ok {
 			break
 		}
+		if rows++; iter.maxRows > 0 && rows > iter.maxRows {
+			iter.err = ErrTooManyRows
+			break
+		}
 
 		// allocate memory for the page data
 		if !alloc {
@@ -234,26 +646,63 @@ func (iter *Iterx) scanAll(dest interface{}) bool {
 // positions to fields to avoid that overhead per scan, which means it is not
 // safe to run StructScan on the same Iterx instance with different struct
 // types.
+//
+// Fields tagged with the required option i.e. `db:"name,required"` must be
+// mapped to a column of the result, otherwise an error is reported unless the
+// iterator is unsafe. This helps catching schema changes that drop a column
+// the code depends on.
+//
+// If the struct has a map[string]interface{} field tagged with the extra
+// option i.e. `db:",extra"` columns that cannot be mapped to any other field
+// are collected into that map instead of being reported as an error.
+//
+// If dest implements RowUnmarshaler the row is decoded by UnmarshalRow.
 func (iter *Iterx) StructScan(dest interface{}) bool {
 	v := reflect.ValueOf(dest)
 	if v.Kind() != reflect.Ptr {
 		iter.err = errors.New("must pass a pointer, not a value, to StructScan destination")
 		return false
 	}
+	if u, ok := dest.(RowUnmarshaler); ok {
+		return iter.rowScan(u)
+	}
 
 	if !iter.started {
 		columns := columnNames(iter.Iter.Columns())
 		m := iter.Mapper
 
-		iter.fields = m.TraversalsByName(v.Type(), columns)
+		iter.fields = iter.traversals(v.Type(), columns)
+		iter.extra = extraField(m, reflectx.Deref(v.Type()), iter.fields)
 		// if we are not unsafe and are missing fields, return an error
-		if !iter.unsafe {
+		if iter.extra != nil {
+			iter.extraValues = make([]rowValue, len(columns))
+		} else if !iter.unsafe {
 			if f, err := missingFields(iter.fields); err != nil {
-				iter.err = fmt.Errorf("missing destination name %q in %T", columns[f], dest)
+				iter.err = &MissingDestinationError{Column: columns[f], Type: reflect.TypeOf(dest)}
+				return false
+			}
+		} else {
+			iter.dropped = droppedColumns(columns, iter.fields)
+		}
+		if !iter.unsafe {
+			if name, ok := missingRequired(m, reflectx.Deref(v.Type()), iter.fields); ok {
+				iter.err = &MissingRequiredError{Column: name, Type: reflect.TypeOf(dest)}
 				return false
 			}
 		}
 		iter.values = make([]interface{}, len(columns))
+		iter.scanners, iter.dests = columnScanners(iter.Iter.Columns(), reflectx.Deref(v.Type()), iter.fields)
+		if err := iter.applyNullPolicy(reflectx.Deref(v.Type())); err != nil {
+			iter.err = err
+			return false
+		}
+		if iter.extra != nil {
+			for i, traversal := range iter.fields {
+				if len(traversal) == 0 {
+					iter.dests[i] = &iter.extraValues[i]
+				}
+			}
+		}
 		iter.started = true
 	}
 
@@ -262,8 +711,176 @@ func (iter *Iterx) StructScan(dest interface{}) bool {
 		iter.err = err
 		return false
 	}
+	for i, s := range iter.scanners {
+		if s != nil {
+			s.dest = iter.values[i]
+		}
+	}
 	// scan into the struct field pointers and append to our results
-	return iter.Iter.Scan(iter.values...)
+	if !iter.Scan(iter.dests...) {
+		return false
+	}
+	if iter.extra != nil {
+		iter.setExtra(v)
+	}
+	return true
+}
+
+// extraField returns index of a map[string]interface{} field tagged with the
+// extra option i.e. `db:",extra"`, such field collects the columns that
+// cannot be mapped to any other field. If the extra field was matched by
+// a column name the column is treated as unmapped.
+func extraField(m *reflectx.Mapper, t reflect.Type, traversals [][]int) []int {
+	for _, fi := range m.TypeMap(t).Index {
+		if _, ok := fi.Options["extra"]; !ok || fi.Field.Type != mapType {
+			continue
+		}
+		for i, traversal := range traversals {
+			if reflect.DeepEqual(traversal, fi.Index) {
+				traversals[i] = nil
+			}
+		}
+		return fi.Index
+	}
+	return nil
+}
+
+// traversals returns field indexes of t for columns, column names are
+// resolved according to the NestedColumns and IgnoreCase options and fields
+// rejected by the FieldFilter are treated as unmapped.
+func (iter *Iterx) traversals(t reflect.Type, columns []string) [][]int {
+	if iter.nested {
+		columns = nestedNames(iter.Mapper, t, columns)
+	}
+	if iter.ignoreCase {
+		columns = foldedNames(iter.Mapper, t, columns)
+	}
+	return filterTraversals(t, iter.Mapper.TraversalsByName(t, columns), iter.filter)
+}
+
+// missingRequired returns name of the first field tagged with the required
+// option i.e. `db:"name,required"` that is not mapped to any column.
+func missingRequired(m *reflectx.Mapper, t reflect.Type, traversals [][]int) (string, bool) {
+	for _, fi := range m.TypeMap(t).Index {
+		if _, ok := fi.Options["required"]; !ok {
+			continue
+		}
+		mapped := false
+		for _, traversal := range traversals {
+			if reflect.DeepEqual(traversal, fi.Index) {
+				mapped = true
+				break
+			}
+		}
+		if !mapped {
+			return fi.Path, true
+		}
+	}
+	return "", false
+}
+
+func (iter *Iterx) setExtra(v reflect.Value) {
+	f := reflectx.FieldByIndexes(reflect.Indirect(v), iter.extra)
+	extra := make(map[string]interface{})
+	for i, traversal := range iter.fields {
+		if len(traversal) == 0 {
+			extra[iter.Iter.Columns()[i].Name] = iter.extraValues[i].value
+		}
+	}
+	f.Set(reflect.ValueOf(extra))
+}
+
+// columnScanner wraps a struct field pointer to report the column and field
+// involved when unmarshalling fails.
+type columnScanner struct {
+	column  string
+	field   string
+	dest    interface{}
+	notNull bool
+}
+
+func (s *columnScanner) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if data == nil && s.notNull {
+		return fmt.Errorf("cannot scan NULL column %q (%s) into field %s (%s)",
+			s.column, info.Type(), s.field, reflect.TypeOf(s.dest).Elem())
+	}
+	if err := gocql.Unmarshal(info, data, s.dest); err != nil {
+		return fmt.Errorf("cannot scan column %q (%s) into field %s (%s): %s",
+			s.column, info.Type(), s.field, reflect.TypeOf(s.dest).Elem(), err)
+	}
+	return nil
+}
+
+// columnScanners returns scanners for columns with a matching field in struct
+// type t and a slice of scan destinations referencing them, unmatched columns
+// are skipped.
+func columnScanners(ci []gocql.ColumnInfo, t reflect.Type, traversals [][]int) ([]*columnScanner, []interface{}) {
+	scanners := make([]*columnScanner, len(ci))
+	dests := make([]interface{}, len(ci))
+	for i, traversal := range traversals {
+		if len(traversal) == 0 {
+			continue
+		}
+		scanners[i] = &columnScanner{
+			column: ci[i].Name,
+			field:  fieldPath(t, traversal),
+		}
+		dests[i] = scanners[i]
+	}
+	return scanners, dests
+}
+
+// applyNullPolicy checks the fields of struct type t mapped to columns
+// against the iterator NullPolicy.
+func (iter *Iterx) applyNullPolicy(t reflect.Type) error {
+	if iter.nullPolicy == NullZero {
+		return nil
+	}
+	for i, s := range iter.scanners {
+		if s == nil {
+			continue
+		}
+		ft := t.FieldByIndex(iter.fields[i]).Type
+		if nullable(ft) {
+			continue
+		}
+		if iter.nullPolicy == NullRequirePointer {
+			return fmt.Errorf("field %s (%s) mapped to column %q cannot represent NULL, use a pointer", s.field, ft, s.column)
+		}
+		s.notNull = true
+	}
+	return nil
+}
+
+// fieldPath returns a human readable path of the field like User.Address.City.
+func fieldPath(t reflect.Type, traversal []int) string {
+	name := t.Name()
+	if name == "" {
+		name = t.String()
+	}
+	for _, i := range traversal {
+		f := t.Field(i)
+		name += "." + f.Name
+		t = reflectx.Deref(f.Type)
+	}
+	return name
+}
+
+// Dropped returns names of the result columns that were ignored by
+// StructScan in unsafe mode because they could not be mapped to any
+// destination field. It can be used to log schema drift.
+func (iter *Iterx) Dropped() []string {
+	return iter.dropped
+}
+
+func droppedColumns(columns []string, traversals [][]int) []string {
+	var dropped []string
+	for i, t := range traversals {
+		if len(t) == 0 {
+			dropped = append(dropped, columns[i])
+		}
+	}
+	return dropped
 }
 
 func columnNames(ci []gocql.ColumnInfo) []string {
@@ -274,6 +891,109 @@ func columnNames(ci []gocql.ColumnInfo) []string {
 	return r
 }
 
+// ColumnNames returns names of the result columns in the order of the
+// result, names are result aliases if set.
+func (iter *Iterx) ColumnNames() []string {
+	return columnNames(iter.Columns())
+}
+
+// ColumnTypes returns types of the result columns keyed by column name. It can
+// be used with gocql.TypeInfo.New to create scan destinations dynamically.
+func (iter *Iterx) ColumnTypes() map[string]gocql.TypeInfo {
+	ci := iter.Columns()
+	r := make(map[string]gocql.TypeInfo, len(ci))
+	for _, column := range ci {
+		r[column.Name] = column.TypeInfo
+	}
+	return r
+}
+
+// Buffered reads all the remaining rows into memory so that they can be
+// iterated over multiple times, see Reset. If there are more than maxRows rows
+// an error is reported, maxRows <= 0 disables the check. Buffered should only
+// be used for small result sets.
+func (iter *Iterx) Buffered(maxRows int) *Iterx {
+	columns := iter.Iter.Columns()
+	values := make([]rawColumn, len(columns))
+	dests := make([]interface{}, len(columns))
+	for i := range values {
+		dests[i] = &values[i]
+	}
+
+	for iter.Iter.Scan(dests...) {
+		if maxRows > 0 && len(iter.rows) >= maxRows {
+			iter.err = fmt.Errorf("buffered iterator exceeded %d rows", maxRows)
+			break
+		}
+		row := make([][]byte, len(values))
+		for i := range values {
+			row[i] = values[i].data
+		}
+		iter.rows = append(iter.rows, row)
+	}
+	iter.Close()
+
+	iter.buffered = true
+	iter.pos = 0
+	return iter
+}
+
+// Reset rewinds a buffered iterator to the first row, it clears any errors
+// that happened during the previous iteration. It has no effect if the
+// iterator is not buffered.
+func (iter *Iterx) Reset() {
+	if !iter.buffered {
+		return
+	}
+	iter.pos = 0
+	iter.err = nil
+}
+
+// Scan is like gocql.Iter.Scan, if the iterator is buffered it scans the
+// buffered rows.
+func (iter *Iterx) Scan(dest ...interface{}) bool {
+	if !iter.buffered {
+		return iter.Iter.Scan(dest...)
+	}
+
+	if iter.err != nil || iter.pos >= len(iter.rows) {
+		return false
+	}
+
+	columns := iter.Iter.Columns()
+	if len(dest) != len(columns) {
+		iter.err = fmt.Errorf("not enough columns to scan into: have %d want %d", len(dest), len(columns))
+		return false
+	}
+	row := iter.rows[iter.pos]
+	for i, c := range columns {
+		if dest[i] == nil {
+			continue
+		}
+		if err := gocql.Unmarshal(c.TypeInfo, row[i], dest[i]); err != nil {
+			iter.err = err
+			return false
+		}
+	}
+	iter.pos++
+
+	return true
+}
+
+// rawColumn keeps a copy of the column bytes.
+type rawColumn struct {
+	data []byte
+}
+
+func (c *rawColumn) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if data == nil {
+		c.data = nil
+	} else {
+		c.data = append(make([]byte, 0, len(data)), data...)
+	}
+	return nil
+}
+
 // Close closes the iterator and returns any errors that happened during
 // the query or the iteration.
 func (iter *Iterx) Close() error {
@@ -281,13 +1001,44 @@ func (iter *Iterx) Close() error {
 	if iter.err == nil {
 		iter.err = err
 	}
+	if iter.query != nil {
+		iter.attempts = iter.query.Attempts()
+		iter.latency = iter.query.Latency()
+	}
 	return iter.err
 }
 
+// WillSwitchPage detects if the iterator reached the end of the current page
+// and the next call to Scan or StructScan will fetch the next page. It can be
+// used to save PageState right before the next page is fetched. Buffered
+// iterators never switch pages.
+func (iter *Iterx) WillSwitchPage() bool {
+	if iter.buffered {
+		return false
+	}
+	return iter.Iter.WillSwitchPage()
+}
+
+// Attempts returns the number of times the query was executed, it's
+// available after the iterator is closed.
+func (iter *Iterx) Attempts() int {
+	return iter.attempts
+}
+
+// Latency returns the average amount of nanoseconds per attempt of the query,
+// it's available after the iterator is closed.
+func (iter *Iterx) Latency() int64 {
+	return iter.latency
+}
+
 // checkErrAndNotFound handle error and NotFound in one method.
 func (iter *Iterx) checkErrAndNotFound() error {
 	if iter.err != nil {
 		return iter.err
+	} else if iter.buffered {
+		if len(iter.rows) == 0 {
+			return gocql.ErrNotFound
+		}
 	} else if iter.Iter.NumRows() == 0 {
 		return gocql.ErrNotFound
 	}
@@ -7,13 +7,21 @@
 package gocqlx_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"math/big"
+	"net"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
 	"github.com/scylladb/gocqlx"
 	. "github.com/scylladb/gocqlx/gocqlxtest"
 	"github.com/scylladb/gocqlx/qb"
@@ -296,12 +304,69 @@ func TestStructOnly(t *testing.T) {
 		}
 	})
 
+	t.Run("DefaultStructOnly select", func(t *testing.T) {
+		gocqlx.DefaultStructOnly = true
+		defer func() { gocqlx.DefaultStructOnly = false }()
+		var v []FullName
+		if err := gocqlx.Iter(session.Query(`SELECT first_name, last_name FROM struct_only_table`)).Select(&v); err != nil {
+			t.Fatal("select failed", err)
+		}
+
+		if len(v) != 1 {
+			t.Fatal("select unexpected number of rows", len(v))
+		}
+
+		if !reflect.DeepEqual(m, v[0]) {
+			t.Fatal("not equals")
+		}
+	})
+
+	t.Run("DefaultStructOnly scalar", func(t *testing.T) {
+		gocqlx.DefaultStructOnly = true
+		defer func() { gocqlx.DefaultStructOnly = false }()
+		var count int
+		if err := gocqlx.Iter(session.Query(`SELECT count(*) FROM struct_only_table`)).Get(&count); err != nil {
+			t.Fatal("get failed", err)
+		}
+		if count != 1 {
+			t.Fatal("unexpected count", count)
+		}
+		var names []string
+		if err := gocqlx.Iter(session.Query(`SELECT first_name FROM struct_only_table`)).Select(&names); err != nil {
+			t.Fatal("select failed", err)
+		}
+		if len(names) != 1 || names[0] != m.FirstName {
+			t.Fatal("unexpected names", names)
+		}
+	})
+
+	t.Run("StructOnly scalar error", func(t *testing.T) {
+		var count int
+		if err := gocqlx.Iter(session.Query(`SELECT count(*) FROM struct_only_table`)).StructOnly().Get(&count); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("DefaultStructOnly allow scannable", func(t *testing.T) {
+		gocqlx.DefaultStructOnly = true
+		defer func() { gocqlx.DefaultStructOnly = false }()
+		var v FullName
+		err := gocqlx.Iter(session.Query(`SELECT first_name, last_name FROM struct_only_table`)).AllowScannable().Get(&v)
+		if !errors.Is(err, gocqlx.ErrColumnCount) {
+			t.Fatal("get expected validation error got", err)
+		}
+	})
+
 	t.Run("get error", func(t *testing.T) {
 		var v FullName
 		err := gocqlx.Iter(session.Query(`SELECT first_name, last_name FROM struct_only_table`)).Get(&v)
 		if err == nil || !strings.HasPrefix(err.Error(), "expected 1 column in result") {
 			t.Fatal("get expected validation error got", err)
 		}
+		var e *gocqlx.ColumnCountError
+		if !errors.As(err, &e) || e.Columns != 2 || !errors.Is(err, gocqlx.ErrColumnCount) {
+			t.Fatal("expected ColumnCountError got", err)
+		}
 	})
 
 	t.Run("select error", func(t *testing.T) {
@@ -401,9 +466,14 @@ func TestUnsafe(t *testing.T) {
 	t.Run("safe get", func(t *testing.T) {
 		var v UnsafeTable
 		i := gocqlx.Iter(session.Query(`SELECT * FROM unsafe_table`))
-		if err := i.Get(&v); err == nil || err.Error() != "missing destination name \"testtextunbound\" in *gocqlx_test.UnsafeTable" {
+		err := i.Get(&v)
+		if err == nil || err.Error() != "missing destination name \"testtextunbound\" in *gocqlx_test.UnsafeTable" {
 			t.Fatal("expected ErrNotFound", "got", err)
 		}
+		var e *gocqlx.MissingDestinationError
+		if !errors.As(err, &e) || e.Column != "testtextunbound" || !errors.Is(err, gocqlx.ErrMissingDestination) {
+			t.Fatal("expected MissingDestinationError got", err)
+		}
 	})
 
 	t.Run("safe select", func(t *testing.T) {
@@ -428,6 +498,17 @@ func TestUnsafe(t *testing.T) {
 		}
 	})
 
+	t.Run("unsafe dropped", func(t *testing.T) {
+		var v UnsafeTable
+		i := gocqlx.Iter(session.Query(`SELECT * FROM unsafe_table`))
+		if err := i.Unsafe().Get(&v); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"testtextunbound"}, i.Dropped()); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
 	t.Run("unsafe select", func(t *testing.T) {
 		var v []UnsafeTable
 		i := gocqlx.Iter(session.Query(`SELECT * FROM unsafe_table`))
@@ -552,3 +633,1758 @@ func TestPaging(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateIndex(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.create_index_table (id int PRIMARY KEY, val int)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	stmt, _ := qb.CreateIndex("create_index_table", "val").
+		WithKeyspace("gocqlx_test").
+		Name("create_index_val_index").
+		IfNotExists().
+		ToCql()
+	for i := 0; i < 2; i++ {
+		if err := ExecStmt(session, stmt); err != nil {
+			t.Fatal("create index:", err)
+		}
+	}
+
+	if err := ExecStmt(session, `INSERT INTO gocqlx_test.create_index_table (id, val) VALUES (1, 10)`); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	var id int
+	if err := gocqlx.Query(session.Query(`SELECT id FROM gocqlx_test.create_index_table WHERE val=?`, 10), nil).Get(&id); err != nil {
+		t.Fatal("select by index:", err)
+	}
+	if id != 1 {
+		t.Fatal("expected 1 got", id)
+	}
+}
+
+func TestTypeMismatch(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.type_mismatch_table (id int PRIMARY KEY, age int)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO type_mismatch_table (id, age) values (?, ?)`, 1, 30).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type TypeMismatch struct {
+		ID  int
+		Age string
+	}
+
+	t.Run("get", func(t *testing.T) {
+		var v TypeMismatch
+		err := gocqlx.Iter(session.Query(`SELECT id, age FROM type_mismatch_table`)).Get(&v)
+		if err == nil || !strings.HasPrefix(err.Error(), `cannot scan column "age" (int) into field TypeMismatch.Age (string)`) {
+			t.Fatal("get expected type mismatch error got", err)
+		}
+	})
+
+	t.Run("select", func(t *testing.T) {
+		var v []TypeMismatch
+		err := gocqlx.Iter(session.Query(`SELECT id, age FROM type_mismatch_table`)).Select(&v)
+		if err == nil || !strings.HasPrefix(err.Error(), `cannot scan column "age" (int) into field TypeMismatch.Age (string)`) {
+			t.Fatal("select expected type mismatch error got", err)
+		}
+	})
+}
+
+func TestUDTCollection(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TYPE gocqlx_test.FullNameElem (first_name text, last_name text)`); err != nil {
+		t.Fatal("create type:", err)
+	}
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.udt_collection_table (
+			testuuid   timeuuid PRIMARY KEY,
+			testlist   list<frozen<gocqlx_test.FullNameElem>>,
+			testmap    map<text, frozen<gocqlx_test.FullNameElem>>
+		)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type UDTCollectionTable struct {
+		Testuuid gocql.UUID
+		Testlist []FullNameUDT
+		Testmap  map[string]FullNameUDT
+	}
+
+	m := UDTCollectionTable{
+		Testuuid: gocql.TimeUUID(),
+		Testlist: []FullNameUDT{{"John", "Doe"}, {"Jane", "Roe"}},
+		Testmap: map[string]FullNameUDT{
+			"john": {"John", "Doe"},
+			"jane": {"Jane", "Roe"},
+		},
+	}
+
+	stmt, names := qb.Insert("gocqlx_test.udt_collection_table").Columns("testuuid", "testlist", "testmap").ToCql()
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(m).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	t.Run("get", func(t *testing.T) {
+		var v UDTCollectionTable
+		if err := gocqlx.Query(session.Query(`SELECT * FROM udt_collection_table`), nil).Get(&v); err != nil {
+			t.Fatal("get failed", err)
+		}
+
+		if !reflect.DeepEqual(m, v) {
+			t.Fatal("not equals")
+		}
+	})
+
+	t.Run("select", func(t *testing.T) {
+		var v []UDTCollectionTable
+		if err := gocqlx.Query(session.Query(`SELECT * FROM udt_collection_table`), nil).Select(&v); err != nil {
+			t.Fatal("select failed", err)
+		}
+
+		if len(v) != 1 {
+			t.Fatal("select unexpected number of rows", len(v))
+		}
+
+		if !reflect.DeepEqual(m, v[0]) {
+			t.Fatal("not equals")
+		}
+	})
+}
+
+func TestRowJSON(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TYPE gocqlx_test.FullNameJSON (first_name text, last_name text)`); err != nil {
+		t.Fatal("create type:", err)
+	}
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.row_json_table (
+			testint     int PRIMARY KEY,
+			testtext    text,
+			testbool    boolean,
+			testlist    list<text>,
+			testmap     map<text, int>,
+			testudt     gocqlx_test.FullNameJSON,
+			testnull    text
+		)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO row_json_table (testint, testtext, testbool, testlist, testmap, testudt) values (?, ?, ?, ?, ?, ?)`,
+		1, "text", true, []string{"a", "b"}, map[string]int{"x": 1}, FullNameUDT{"John", "Doe"}).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	iter := gocqlx.Iter(session.Query(`SELECT testint, testtext, testbool, testlist, testmap, testudt, testnull FROM row_json_table`))
+	b, err := iter.RowJSON()
+	if err != nil {
+		t.Fatal("row json:", err)
+	}
+	const golden = `{"testint":1,"testtext":"text","testbool":true,"testlist":["a","b"],"testmap":{"x":1},"testudt":{"first_name":"John","last_name":"Doe"},"testnull":null}`
+	if string(b) != golden {
+		t.Fatal("expected", golden, "got", string(b))
+	}
+
+	b, err = iter.RowJSON()
+	if err != nil {
+		t.Fatal("row json:", err)
+	}
+	if b != nil {
+		t.Fatal("expected no more rows got", string(b))
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.export_csv_table (k int, id int, name text, tags list<text>, score double, PRIMARY KEY (k, id))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO gocqlx_test.export_csv_table (k, id, name, tags, score) VALUES (1, 1, 'Doe, John', ['a', 'b'], 1.5)`).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+	if err := session.Query(`INSERT INTO gocqlx_test.export_csv_table (k, id, name) VALUES (1, 2, 'foo')`).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	var buf bytes.Buffer
+	iter := gocqlx.Iter(session.Query(`SELECT id, name, tags, score FROM gocqlx_test.export_csv_table WHERE k = 1`))
+	if err := gocqlx.ExportCSV(iter, &buf); err != nil {
+		t.Fatal("export:", err)
+	}
+
+	const golden = "id,name,tags,score\n" +
+		"1,\"Doe, John\",\"[\"\"a\"\",\"\"b\"\"]\",1.5\n" +
+		"2,foo,,\n"
+	if diff := cmp.Diff(golden, buf.String()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestBuffered(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.buffered_table (id int PRIMARY KEY, val text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	stmt, names := qb.Insert("gocqlx_test.buffered_table").Columns("id", "val").ToCql()
+	q := gocqlx.Query(session.Query(stmt), names)
+	for i := 0; i < 10; i++ {
+		if err := q.Bind(i, fmt.Sprint(i)).Exec(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type Buffered struct {
+		ID  int
+		Val string
+	}
+
+	t.Run("select twice", func(t *testing.T) {
+		iter := gocqlx.Iter(session.Query(`SELECT * FROM buffered_table`).PageSize(3)).Buffered(100)
+
+		var a []Buffered
+		if err := iter.Select(&a); err != nil {
+			t.Fatal("select failed", err)
+		}
+		if len(a) != 10 {
+			t.Fatal("select unexpected number of rows", len(a))
+		}
+
+		iter.Reset()
+
+		var b []Buffered
+		if err := iter.Select(&b); err != nil {
+			t.Fatal("select failed", err)
+		}
+		if !reflect.DeepEqual(a, b) {
+			t.Fatal("not equals")
+		}
+	})
+
+	t.Run("struct scan twice", func(t *testing.T) {
+		iter := gocqlx.Iter(session.Query(`SELECT * FROM buffered_table`)).Buffered(100)
+		defer iter.Close()
+
+		var sum [2]int
+		for i := range sum {
+			var v Buffered
+			for iter.StructScan(&v) {
+				sum[i] += v.ID
+			}
+			iter.Reset()
+		}
+		if sum[0] != 45 || sum[0] != sum[1] {
+			t.Fatal("unexpected sums", sum)
+		}
+	})
+
+	t.Run("max rows", func(t *testing.T) {
+		var v []Buffered
+		err := gocqlx.Iter(session.Query(`SELECT * FROM buffered_table`)).Buffered(5).Select(&v)
+		if err == nil || err.Error() != "buffered iterator exceeded 5 rows" {
+			t.Fatal("expected max rows error got", err)
+		}
+	})
+}
+
+func TestBindMapField(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TYPE gocqlx_test.FullNameValue (first_name text, last_name text)`); err != nil {
+		t.Fatal("create type:", err)
+	}
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.bind_map_field_table (
+			id        int PRIMARY KEY,
+			testudts  map<text, frozen<gocqlx_test.FullNameValue>>,
+			testblobs map<text, blob>
+		)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type BindMapFieldTable struct {
+		ID        int
+		Testudts  map[string]FullNameUDT
+		Testblobs map[string][]byte
+	}
+
+	m := BindMapFieldTable{
+		ID: 1,
+		Testudts: map[string]FullNameUDT{
+			"john": {"John", "Doe"},
+			"jane": {"Jane", "Roe"},
+		},
+		Testblobs: map[string][]byte{
+			"a": []byte("blob a"),
+			"b": []byte("blob b"),
+		},
+	}
+
+	stmt, names := qb.Insert("gocqlx_test.bind_map_field_table").Columns("id", "testudts", "testblobs").ToCql()
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(m).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	stmt, names = qb.Select("gocqlx_test.bind_map_field_table").Where(qb.Eq("id")).ToCql()
+	var v BindMapFieldTable
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(m).GetRelease(&v); err != nil {
+		t.Fatal("get failed", err)
+	}
+	if !reflect.DeepEqual(m, v) {
+		t.Fatal("not equals")
+	}
+}
+
+func TestExtra(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.extra_table (id int PRIMARY KEY, name text, age int, note text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO extra_table (id, name, age) values (?, ?, ?)`, 1, "John", 42).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type ExtraTable struct {
+		ID    int
+		Name  string
+		Extra map[string]interface{} `db:",extra"`
+	}
+
+	t.Run("get", func(t *testing.T) {
+		var v ExtraTable
+		if err := gocqlx.Iter(session.Query(`SELECT * FROM extra_table`)).Get(&v); err != nil {
+			t.Fatal(err)
+		}
+		expected := ExtraTable{
+			ID:   1,
+			Name: "John",
+			Extra: map[string]interface{}{
+				"age":  42,
+				"note": nil,
+			},
+		}
+		if diff := cmp.Diff(expected, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("select", func(t *testing.T) {
+		var v []ExtraTable
+		if err := gocqlx.Iter(session.Query(`SELECT id, name, age FROM extra_table`)).Select(&v); err != nil {
+			t.Fatal(err)
+		}
+		expected := []ExtraTable{{
+			ID:    1,
+			Name:  "John",
+			Extra: map[string]interface{}{"age": 42},
+		}}
+		if diff := cmp.Diff(expected, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("no extra columns", func(t *testing.T) {
+		var v ExtraTable
+		if err := gocqlx.Iter(session.Query(`SELECT id, name FROM extra_table`)).Get(&v); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(map[string]interface{}{}, v.Extra); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestQueryStats(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.query_stats_table (id int PRIMARY KEY)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO query_stats_table (id) values (?)`, 1).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	var v []int
+	i := gocqlx.Iter(session.Query(`SELECT id FROM query_stats_table`))
+	if err := i.Select(&v); err != nil {
+		t.Fatal(err)
+	}
+	if i.Attempts() < 1 {
+		t.Fatal("expected at least 1 attempt got", i.Attempts())
+	}
+	if i.Latency() <= 0 {
+		t.Fatal("expected latency got", i.Latency())
+	}
+
+	t.Run("last", func(t *testing.T) {
+		q := gocqlx.Query(session.Query(`SELECT id FROM query_stats_table WHERE id = ?`), nil).Bind(1)
+
+		var id int
+		if err := q.Get(&id); err != nil {
+			t.Fatal("get:", err)
+		}
+		if q.LastAttempts() != 1 {
+			t.Fatal("expected 1 attempt got", q.LastAttempts())
+		}
+		if q.LastLatency() <= 0 {
+			t.Fatal("expected latency got", q.LastLatency())
+		}
+
+		var ids []int
+		if err := q.Select(&ids); err != nil {
+			t.Fatal("select:", err)
+		}
+		if q.LastAttempts() != 1 {
+			t.Fatal("expected 1 attempt got", q.LastAttempts())
+		}
+		if q.Attempts() != 2 {
+			t.Fatal("expected 2 total attempts got", q.Attempts())
+		}
+		if q.LastLatency() <= 0 {
+			t.Fatal("expected latency got", q.LastLatency())
+		}
+	})
+}
+
+func TestGroupByCount(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.group_by_count_table (k text, c int, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	rows := []struct {
+		K string
+		C int
+	}{
+		{"a", 1}, {"a", 2}, {"a", 3}, {"b", 1},
+	}
+	for _, r := range rows {
+		if err := session.Query(`INSERT INTO group_by_count_table (k, c) values (?, ?)`, r.K, r.C).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	type GroupCount struct {
+		K     string
+		Count int
+	}
+
+	stmt, names := qb.Select("gocqlx_test.group_by_count_table").
+		Columns("k").
+		CountAll().As("count(*)", "count").
+		GroupBy("k").
+		ToCql()
+
+	var v []GroupCount
+	if err := gocqlx.Query(session.Query(stmt), names).SelectRelease(&v); err != nil {
+		t.Fatal("select:", err)
+	}
+	sort.Slice(v, func(i, j int) bool { return v[i].K < v[j].K })
+
+	expected := []GroupCount{{"a", 3}, {"b", 1}}
+	if diff := cmp.Diff(expected, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestGroupByNullAggregate(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.group_by_null_table (k text, c int, v int, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for _, c := range []int{1, 2} {
+		if err := session.Query(`INSERT INTO group_by_null_table (k, c, v) values ('a', ?, ?)`, c, c*10).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+		if err := session.Query(`INSERT INTO group_by_null_table (k, c) values ('b', ?)`, c).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	type GroupMax struct {
+		K    string
+		MaxV *int
+	}
+
+	stmt, names := qb.Select("gocqlx_test.group_by_null_table").
+		Max("v").As("max(v)", "max_v").
+		GroupBy("k").
+		ToCql()
+
+	var v []GroupMax
+	if err := gocqlx.Query(session.Query(stmt), names).SelectRelease(&v); err != nil {
+		t.Fatal("select:", err)
+	}
+	sort.Slice(v, func(i, j int) bool { return v[i].K < v[j].K })
+
+	max := 20
+	expected := []GroupMax{{"a", &max}, {"b", nil}}
+	if diff := cmp.Diff(expected, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestBindStructUnsetEmpty(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.unset_empty_table (id int PRIMARY KEY, name text, age int)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type UnsetEmptyTable struct {
+		ID   int
+		Name string
+		Age  int
+	}
+
+	stmt, names := qb.Insert("gocqlx_test.unset_empty_table").Columns("id", "name", "age").ToCql()
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(UnsetEmptyTable{1, "John", 42}).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+	if err := gocqlx.Query(session.Query(stmt), names).BindStructUnsetEmpty(UnsetEmptyTable{ID: 1, Age: 43}).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	stmt, names = qb.Select("gocqlx_test.unset_empty_table").Where(qb.Eq("id")).ToCql()
+	var v UnsetEmptyTable
+	if err := gocqlx.Query(session.Query(stmt), names).Bind(1).GetRelease(&v); err != nil {
+		t.Fatal("get:", err)
+	}
+	if diff := cmp.Diff(UnsetEmptyTable{1, "John", 43}, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestAnonymousStruct(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.anonymous_struct_table (id int PRIMARY KEY, name text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i, name := range []string{"a", "b"} {
+		if err := session.Query(`INSERT INTO anonymous_struct_table (id, name) values (?, ?)`, i, name).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	t.Run("select", func(t *testing.T) {
+		var v []struct {
+			ID   int
+			Name string
+		}
+		if err := gocqlx.Iter(session.Query(`SELECT id, name FROM anonymous_struct_table`)).Select(&v); err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(v, func(i, j int) bool { return v[i].ID < v[j].ID })
+
+		expected := []struct {
+			ID   int
+			Name string
+		}{{0, "a"}, {1, "b"}}
+		if diff := cmp.Diff(expected, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		var v struct {
+			ID   int
+			Name string `db:"name"`
+		}
+		if err := gocqlx.Iter(session.Query(`SELECT id, name FROM anonymous_struct_table WHERE id=1`)).Get(&v); err != nil {
+			t.Fatal(err)
+		}
+		if v.ID != 1 || v.Name != "b" {
+			t.Fatal("get failed", v)
+		}
+	})
+}
+
+func TestWillSwitchPage(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.will_switch_page_table (k int, c int, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := session.Query(`INSERT INTO will_switch_page_table (k, c) values (?, ?)`, 1, i).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	type WillSwitchPageTable struct {
+		K int
+		C int
+	}
+
+	iter := gocqlx.Iter(session.Query(`SELECT * FROM will_switch_page_table WHERE k=1`).PageSize(2))
+	var (
+		v        WillSwitchPageTable
+		switches []int
+	)
+	for iter.StructScan(&v) {
+		if iter.WillSwitchPage() {
+			switches = append(switches, v.C)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		t.Fatal("close:", err)
+	}
+	if diff := cmp.Diff([]int{1, 3}, switches); diff != "" {
+		t.Fatal(diff)
+	}
+
+	for _, n := range []int{0, -1} {
+		iter := gocqlx.Query(session.Query(`SELECT * FROM will_switch_page_table WHERE k=1`), nil).PageSize(n).Iter()
+		if !iter.StructScan(&v) {
+			t.Fatal("scan:", iter.Close())
+		}
+		if iter.NumRows() != 5 || len(iter.PageState()) != 0 {
+			t.Fatal("expected paging disabled for page size", n)
+		}
+		if err := iter.Close(); err != nil {
+			t.Fatal("close:", err)
+		}
+	}
+}
+
+func TestSelectAppend(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.select_append_table (k int, c int, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for k := 0; k < 2; k++ {
+		for c := 0; c < 3; c++ {
+			if err := session.Query(`INSERT INTO select_append_table (k, c) values (?, ?)`, k, c).Exec(); err != nil {
+				t.Fatal("insert:", err)
+			}
+		}
+	}
+
+	type SelectAppendTable struct {
+		K int
+		C int
+	}
+
+	t.Run("struct", func(t *testing.T) {
+		var v []SelectAppendTable
+		for k := 0; k < 2; k++ {
+			if err := gocqlx.Iter(session.Query(`SELECT * FROM select_append_table WHERE k=?`, k)).SelectAppend(&v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(v) != 6 {
+			t.Fatal("expected 6 rows got", len(v))
+		}
+		if v[0].K != 0 || v[5].K != 1 {
+			t.Fatal("unexpected order", v)
+		}
+	})
+
+	t.Run("scannable", func(t *testing.T) {
+		v := []int{-1}
+		for k := 0; k < 2; k++ {
+			if err := gocqlx.Iter(session.Query(`SELECT c FROM select_append_table WHERE k=?`, k)).SelectAppend(&v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if diff := cmp.Diff([]int{-1, 0, 1, 2, 0, 1, 2}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("select replaces", func(t *testing.T) {
+		v := []int{-1}
+		if err := gocqlx.Iter(session.Query(`SELECT c FROM select_append_table WHERE k=0`)).Select(&v); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{0, 1, 2}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+type AddressUDT struct {
+	StreetName string
+	HouseNo    int
+	Zip        string `db:"postal_code"`
+}
+
+func (a AddressUDT) MarshalUDT(name string, info gocql.TypeInfo) ([]byte, error) {
+	return gocqlx.MarshalUDT(a, name, info)
+}
+
+func (a *AddressUDT) UnmarshalUDT(name string, info gocql.TypeInfo, data []byte) error {
+	return gocqlx.UnmarshalUDT(a, name, info, data)
+}
+
+func TestUDTMapping(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TYPE gocqlx_test.address (street_name text, house_no int, postal_code text)`); err != nil {
+		t.Fatal("create type:", err)
+	}
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.udt_mapping_table (id int PRIMARY KEY, address frozen<gocqlx_test.address>)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type UDTMappingTable struct {
+		ID      int
+		Address AddressUDT
+	}
+
+	m := UDTMappingTable{
+		ID:      1,
+		Address: AddressUDT{StreetName: "Main", HouseNo: 7, Zip: "00-001"},
+	}
+
+	stmt, names := qb.Insert("gocqlx_test.udt_mapping_table").Columns("id", "address").ToCql()
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(m).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	stmt, names = qb.Select("gocqlx_test.udt_mapping_table").Where(qb.Eq("id")).ToCql()
+	var v UDTMappingTable
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(m).GetRelease(&v); err != nil {
+		t.Fatal("get:", err)
+	}
+	if diff := cmp.Diff(m, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestPartialScan(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.partial_scan_table (id int PRIMARY KEY, name text, age int, email text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO partial_scan_table (id, name, age, email) values (?, ?, ?, ?)`, 1, "John", 42, "john@example.com").Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type PartialScanTable struct {
+		ID    int
+		Name  string
+		Age   int
+		Email string
+	}
+
+	t.Run("get", func(t *testing.T) {
+		var v PartialScanTable
+		if err := gocqlx.Iter(session.Query(`SELECT id, name FROM partial_scan_table`)).Get(&v); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(PartialScanTable{ID: 1, Name: "John"}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("select", func(t *testing.T) {
+		var v []PartialScanTable
+		if err := gocqlx.Iter(session.Query(`SELECT age FROM partial_scan_table`)).Select(&v); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]PartialScanTable{{Age: 42}}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("unmapped column", func(t *testing.T) {
+		type Narrow struct {
+			ID int
+		}
+		var v Narrow
+		err := gocqlx.Iter(session.Query(`SELECT id, name FROM partial_scan_table`)).Get(&v)
+		if err == nil || !strings.HasPrefix(err.Error(), `missing destination name "name"`) {
+			t.Fatal("expected missing destination error got", err)
+		}
+	})
+}
+
+// TestInet checks that net.IP fields are bound to and scanned from inet
+// columns, both IPv4 and IPv6 addresses round-trip unchanged.
+func TestInet(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.inet_table (id int PRIMARY KEY, ip inet)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type InetTable struct {
+		ID int
+		IP net.IP
+	}
+
+	table := []InetTable{
+		{1, net.ParseIP("127.0.0.1").To4()},
+		{2, net.ParseIP("2001:db8::68")},
+	}
+
+	insert, insertNames := qb.Insert("gocqlx_test.inet_table").Columns("id", "ip").ToCql()
+	get, getNames := qb.Select("gocqlx_test.inet_table").Where(qb.Eq("id")).ToCql()
+
+	for _, m := range table {
+		if err := gocqlx.Query(session.Query(insert), insertNames).BindStruct(m).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+
+		var v InetTable
+		if err := gocqlx.Query(session.Query(get), getNames).BindStruct(m).GetRelease(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if !v.IP.Equal(m.IP) {
+			t.Fatalf("got %s expected %s", v.IP, m.IP)
+		}
+	}
+}
+
+func TestTimeDate(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.time_date_table (id int PRIMARY KEY, t time, d date, ts_date date)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type TimeDateTable struct {
+		ID     int
+		T      time.Duration
+		D      gocqlx.Date
+		TSDate time.Time `db:"ts_date"`
+	}
+
+	table := []TimeDateTable{
+		{1, 0, 0, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{2, 24*time.Hour - time.Nanosecond, math.MaxInt32, time.Date(2020, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{3, 12*time.Hour + 30*time.Minute, math.MinInt32, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	insert, insertNames := qb.Insert("gocqlx_test.time_date_table").Columns("id", "t", "d", "ts_date").ToCql()
+	get, getNames := qb.Select("gocqlx_test.time_date_table").Where(qb.Eq("id")).ToCql()
+
+	for _, m := range table {
+		if err := gocqlx.Query(session.Query(insert), insertNames).BindStruct(m).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+
+		var v TimeDateTable
+		if err := gocqlx.Query(session.Query(get), getNames).BindStruct(m).GetRelease(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(m, v); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+}
+
+func TestNestedColumns(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.nested_columns_table (id int PRIMARY KEY, address_city text, address_zip text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO gocqlx_test.nested_columns_table (id, address_city, address_zip) VALUES (1, 'Warsaw', '00-001')`).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type Address struct {
+		City string
+		Zip  string
+	}
+	type Person struct {
+		ID      int
+		Address Address
+	}
+
+	t.Run("get", func(t *testing.T) {
+		var v Person
+		if err := gocqlx.Iter(session.Query(`SELECT * FROM gocqlx_test.nested_columns_table`)).NestedColumns().Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(Person{ID: 1, Address: Address{City: "Warsaw", Zip: "00-001"}}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("get without nested columns", func(t *testing.T) {
+		var v Person
+		err := gocqlx.Iter(session.Query(`SELECT * FROM gocqlx_test.nested_columns_table`)).Get(&v)
+		if err == nil || !strings.HasPrefix(err.Error(), "missing destination name") {
+			t.Fatal("expected missing destination error got", err)
+		}
+	})
+}
+
+func TestIgnoreCase(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.ignore_case_table ("Id" int PRIMARY KEY, "FirstName" text, "LAST_NAME" text, "Email" text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO gocqlx_test.ignore_case_table ("Id", "FirstName", "LAST_NAME", "Email") VALUES (1, 'John', 'Doe', 'john@doe.com')`).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type Person struct {
+		ID        int
+		FirstName string
+		LastName  string
+		Email     string
+	}
+
+	const stmt = `SELECT * FROM gocqlx_test.ignore_case_table`
+	golden := Person{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@doe.com"}
+
+	t.Run("get", func(t *testing.T) {
+		var v Person
+		if err := gocqlx.Iter(session.Query(stmt)).IgnoreCase().Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(golden, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("session", func(t *testing.T) {
+		var v []Person
+		if err := gocqlx.NewSession(session).IgnoreCase().Query(stmt, nil).SelectRelease(&v); err != nil {
+			t.Fatal("select:", err)
+		}
+		if diff := cmp.Diff([]Person{golden}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		var v struct {
+			ID        int
+			FirstName string
+			LastName  string
+		}
+		err := gocqlx.Iter(session.Query(stmt)).IgnoreCase().Get(&v)
+		if err == nil || err.Error() != `missing destination name "Email" in *struct { ID int; FirstName string; LastName string }` {
+			t.Fatal("expected missing destination error got", err)
+		}
+	})
+
+	t.Run("get without ignore case", func(t *testing.T) {
+		var v Person
+		err := gocqlx.Iter(session.Query(stmt)).Get(&v)
+		if err == nil || !strings.HasPrefix(err.Error(), "missing destination name") {
+			t.Fatal("expected missing destination error got", err)
+		}
+	})
+}
+
+func TestNullPolicy(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.null_policy_table (id int PRIMARY KEY, name text, age int)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO gocqlx_test.null_policy_table (id, name) VALUES (1, 'foo')`).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type Person struct {
+		ID   int
+		Name string
+		Age  int
+	}
+	type PersonPtr struct {
+		ID   *int
+		Name *string
+		Age  *int
+	}
+
+	const stmt = `SELECT * FROM gocqlx_test.null_policy_table`
+
+	t.Run("zero", func(t *testing.T) {
+		var v Person
+		if err := gocqlx.Iter(session.Query(stmt)).NullPolicy(gocqlx.NullZero).Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(Person{ID: 1, Name: "foo"}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var v Person
+		err := gocqlx.Iter(session.Query(stmt)).NullPolicy(gocqlx.NullError).Get(&v)
+		if err == nil || !strings.Contains(err.Error(), `cannot scan NULL column "age" (int) into field Person.Age (int)`) {
+			t.Fatal("expected null error got", err)
+		}
+	})
+
+	t.Run("error pointer", func(t *testing.T) {
+		var v PersonPtr
+		if err := gocqlx.Iter(session.Query(stmt)).NullPolicy(gocqlx.NullError).Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if v.Age != nil || v.Name == nil || *v.Name != "foo" {
+			t.Fatal("unexpected value", v)
+		}
+	})
+
+	t.Run("require pointer", func(t *testing.T) {
+		var v Person
+		err := gocqlx.Iter(session.Query(stmt)).NullPolicy(gocqlx.NullRequirePointer).Get(&v)
+		if err == nil || err.Error() != `field Person.ID (int) mapped to column "id" cannot represent NULL, use a pointer` {
+			t.Fatal("expected require pointer error got", err)
+		}
+
+		var p PersonPtr
+		if err := gocqlx.Iter(session.Query(stmt)).NullPolicy(gocqlx.NullRequirePointer).Get(&p); err != nil {
+			t.Fatal("get:", err)
+		}
+	})
+
+	t.Run("session", func(t *testing.T) {
+		var v []Person
+		err := gocqlx.NewSession(session).NullPolicy(gocqlx.NullError).Query(stmt, nil).SelectRelease(&v)
+		if err == nil || !strings.Contains(err.Error(), `cannot scan NULL column "age"`) {
+			t.Fatal("expected null error got", err)
+		}
+	})
+}
+
+func TestFieldFilter(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.field_filter_table (id int PRIMARY KEY, name text, password text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO gocqlx_test.field_filter_table (id, name, password) VALUES (1, 'foo', 'secret')`).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type User struct {
+		ID       int
+		Name     string
+		Password string `gocqlx:"skip"`
+	}
+	skip := func(f reflect.StructField) bool {
+		return f.Tag.Get("gocqlx") != "skip"
+	}
+
+	const stmt = `SELECT * FROM gocqlx_test.field_filter_table`
+
+	t.Run("unsafe", func(t *testing.T) {
+		var v User
+		if err := gocqlx.Iter(session.Query(stmt)).FieldFilter(skip).Unsafe().Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(User{ID: 1, Name: "foo"}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		var v User
+		err := gocqlx.Iter(session.Query(stmt)).FieldFilter(skip).Get(&v)
+		if err == nil || !strings.HasPrefix(err.Error(), `missing destination name "password"`) {
+			t.Fatal("expected missing destination error got", err)
+		}
+	})
+
+	t.Run("session", func(t *testing.T) {
+		s := gocqlx.NewSession(session).FieldFilter(skip)
+
+		stmt, names := qb.Insert("gocqlx_test.field_filter_table").Columns("id", "name").ToCql()
+		if err := s.Query(stmt, names).BindStruct(User{ID: 2, Name: "bar", Password: "secret"}).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+
+		var v []User
+		if err := s.Query(`SELECT id, name FROM gocqlx_test.field_filter_table WHERE id = 2`, nil).SelectRelease(&v); err != nil {
+			t.Fatal("select:", err)
+		}
+		if diff := cmp.Diff([]User{{ID: 2, Name: "bar"}}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestMaxRows(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.max_rows_table (k int, c int, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := session.Query(`INSERT INTO gocqlx_test.max_rows_table (k, c) values (?, ?)`, 1, i).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	const stmt = `SELECT c FROM gocqlx_test.max_rows_table WHERE k=1`
+
+	t.Run("within limit", func(t *testing.T) {
+		var v []int
+		if err := gocqlx.Iter(session.Query(stmt)).MaxRows(5).Select(&v); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{0, 1, 2, 3, 4}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		var v []int
+		err := gocqlx.Iter(session.Query(stmt).PageSize(2)).MaxRows(3).Select(&v)
+		if err != gocqlx.ErrTooManyRows {
+			t.Fatal("expected ErrTooManyRows got", err)
+		}
+		if diff := cmp.Diff([]int{0, 1, 2}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("select append exceeded", func(t *testing.T) {
+		v := []int{-1}
+		err := gocqlx.Iter(session.Query(stmt)).MaxRows(2).SelectAppend(&v)
+		if err != gocqlx.ErrTooManyRows {
+			t.Fatal("expected ErrTooManyRows got", err)
+		}
+		if diff := cmp.Diff([]int{-1, 0, 1}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestSmallInts(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.small_ints_table (id int PRIMARY KEY, tiny tinyint, small smallint)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type SmallIntsTable struct {
+		ID    int
+		Tiny  int8
+		Small int16
+	}
+
+	table := []SmallIntsTable{
+		{1, 0, 0},
+		{2, -1, -1},
+		{3, math.MinInt8, math.MinInt16},
+		{4, math.MaxInt8, math.MaxInt16},
+	}
+
+	insert, insertNames := qb.Insert("gocqlx_test.small_ints_table").Columns("id", "tiny", "small").ToCql()
+	get, getNames := qb.Select("gocqlx_test.small_ints_table").Where(qb.Eq("id")).ToCql()
+
+	for _, m := range table {
+		if err := gocqlx.Query(session.Query(insert), insertNames).BindStruct(m).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+
+		var v SmallIntsTable
+		if err := gocqlx.Query(session.Query(get), getNames).BindStruct(m).GetRelease(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(m, v); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+
+	t.Run("scannable", func(t *testing.T) {
+		var tiny []int8
+		if err := gocqlx.Iter(session.Query(`SELECT tiny FROM gocqlx_test.small_ints_table WHERE id IN (3, 4)`)).Select(&tiny); err != nil {
+			t.Fatal("select:", err)
+		}
+		if diff := cmp.Diff([]int8{math.MinInt8, math.MaxInt8}, tiny); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestSingleColumn(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.single_column_table (k int, id text, tags list<text>, PRIMARY KEY (k, id))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if err := session.Query(`INSERT INTO gocqlx_test.single_column_table (k, id, tags) values (1, ?, ?)`, id, []string{id + "1", id + "2"}).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	t.Run("select", func(t *testing.T) {
+		var v []string
+		if err := gocqlx.Iter(session.Query(`SELECT id FROM gocqlx_test.single_column_table WHERE k=1`)).Select(&v); err != nil {
+			t.Fatal("select:", err)
+		}
+		if diff := cmp.Diff([]string{"a", "b", "c"}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("get collection", func(t *testing.T) {
+		var v []string
+		if err := gocqlx.Iter(session.Query(`SELECT tags FROM gocqlx_test.single_column_table WHERE k=1`)).Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff([]string{"a1", "a2"}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestExecBatchCAS(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.batch_cas_table (k int, c int, v text, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := ExecStmt(session, `INSERT INTO gocqlx_test.batch_cas_table (k, c, v) VALUES (1, 1, 'a')`); err != nil {
+		t.Fatal("insert:", err)
+	}
+	if err := ExecStmt(session, `INSERT INTO gocqlx_test.batch_cas_table (k, c, v) VALUES (1, 2, 'b')`); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type BatchCASTable struct {
+		K int
+		C int
+		V string
+	}
+
+	batch := func(expected1, expected2 string) string {
+		return fmt.Sprintf(`BEGIN BATCH
+UPDATE gocqlx_test.batch_cas_table SET v='x' WHERE k=1 AND c=1 IF v='%s';
+UPDATE gocqlx_test.batch_cas_table SET v='y' WHERE k=1 AND c=2 IF v='%s';
+APPLY BATCH`, expected1, expected2)
+	}
+
+	t.Run("not applied", func(t *testing.T) {
+		var rows []BatchCASTable
+		applied, err := gocqlx.Query(session.Query(batch("a", "z")), nil).ExecBatchCASRelease(&rows)
+		if err != nil {
+			t.Fatal("exec batch cas:", err)
+		}
+		if applied {
+			t.Fatal("expected not applied")
+		}
+		expected := []BatchCASTable{{1, 1, "a"}, {1, 2, "b"}}
+		if diff := cmp.Diff(expected, rows); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("applied", func(t *testing.T) {
+		var rows []BatchCASTable
+		applied, err := gocqlx.Query(session.Query(batch("a", "b")), nil).ExecBatchCASRelease(&rows)
+		if err != nil {
+			t.Fatal("exec batch cas:", err)
+		}
+		if !applied {
+			t.Fatal("expected applied")
+		}
+		if rows != nil {
+			t.Fatal("expected dest untouched got", rows)
+		}
+	})
+}
+
+func TestRequired(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.required_table (id int PRIMARY KEY, name text, email text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := ExecStmt(session, `INSERT INTO gocqlx_test.required_table (id, name, email) VALUES (1, 'name', 'email')`); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	type RequiredTable struct {
+		ID    int
+		Name  string
+		Email string `db:"email,required"`
+	}
+
+	t.Run("present", func(t *testing.T) {
+		var v RequiredTable
+		if err := gocqlx.Iter(session.Query(`SELECT * FROM gocqlx_test.required_table`)).Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if v.Email != "email" {
+			t.Fatal("unexpected value", v)
+		}
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		var v []RequiredTable
+		err := gocqlx.Iter(session.Query(`SELECT id, name FROM gocqlx_test.required_table`)).Select(&v)
+		if err == nil || err.Error() != `missing required column "email" in result for *gocqlx_test.RequiredTable` {
+			t.Fatal("expected missing required column error got", err)
+		}
+	})
+
+	t.Run("absent unsafe", func(t *testing.T) {
+		var v RequiredTable
+		if err := gocqlx.Iter(session.Query(`SELECT id, name FROM gocqlx_test.required_table`)).Unsafe().Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+	})
+
+	t.Run("absent cas", func(t *testing.T) {
+		var v RequiredTable
+		_, err := gocqlx.Iter(session.Query(`UPDATE gocqlx_test.required_table SET name='other' WHERE id=1 IF name='none'`)).GetCAS(&v)
+		if !errors.Is(err, gocqlx.ErrMissingRequired) {
+			t.Fatal("expected missing required column error got", err)
+		}
+	})
+
+	t.Run("absent cas unsafe", func(t *testing.T) {
+		var v RequiredTable
+		applied, err := gocqlx.Iter(session.Query(`UPDATE gocqlx_test.required_table SET name='other' WHERE id=1 IF name='none'`)).Unsafe().GetCAS(&v)
+		if err != nil {
+			t.Fatal("get cas:", err)
+		}
+		if applied || v.Name != "name" {
+			t.Fatal("unexpected result", applied, v)
+		}
+	})
+}
+
+func TestEpochMillis(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.epoch_millis_table (id int PRIMARY KEY, created_at bigint, updated_at timestamp)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type EpochMillisTable struct {
+		ID        int
+		CreatedAt gocqlx.EpochMillis
+		UpdatedAt time.Time
+	}
+
+	ts := time.Date(2020, 2, 3, 10, 20, 30, 123000000, time.UTC)
+	m := EpochMillisTable{ID: 1, CreatedAt: gocqlx.EpochMillis(ts), UpdatedAt: ts}
+
+	insert, insertNames := qb.Insert("gocqlx_test.epoch_millis_table").Columns("id", "created_at", "updated_at").ToCql()
+	if err := gocqlx.Query(session.Query(insert), insertNames).BindStruct(m).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	var ms int64
+	if err := session.Query(`SELECT created_at FROM gocqlx_test.epoch_millis_table WHERE id=1`).Scan(&ms); err != nil {
+		t.Fatal("scan:", err)
+	}
+	if ms != 1580725230123 {
+		t.Fatal("unexpected millis", ms)
+	}
+
+	var v EpochMillisTable
+	if err := gocqlx.Iter(session.Query(`SELECT * FROM gocqlx_test.epoch_millis_table WHERE id=1`)).Get(&v); err != nil {
+		t.Fatal("get:", err)
+	}
+	if !v.CreatedAt.Time().Equal(ts) || !v.UpdatedAt.Equal(ts) {
+		t.Fatal("unexpected value", v)
+	}
+}
+
+func TestCustomElementUnmarshaler(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.custom_element_table (id int PRIMARY KEY, names list<text>)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type CustomElementTable struct {
+		ID    int
+		Names []FullName
+	}
+
+	m := CustomElementTable{
+		ID:    1,
+		Names: []FullName{{"John", "Doe"}, {"Jane", "Roe"}},
+	}
+
+	insert, insertNames := qb.Insert("gocqlx_test.custom_element_table").Columns("id", "names").ToCql()
+	if err := gocqlx.Query(session.Query(insert), insertNames).BindStruct(m).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	t.Run("struct scan", func(t *testing.T) {
+		var v CustomElementTable
+		if err := gocqlx.Iter(session.Query(`SELECT * FROM gocqlx_test.custom_element_table WHERE id=1`)).Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(m, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		var v []FullName
+		if err := gocqlx.Iter(session.Query(`SELECT names FROM gocqlx_test.custom_element_table WHERE id=1`)).Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(m.Names, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("stored as text", func(t *testing.T) {
+		var v []string
+		if err := session.Query(`SELECT names FROM gocqlx_test.custom_element_table WHERE id=1`).Scan(&v); err != nil {
+			t.Fatal("scan:", err)
+		}
+		if diff := cmp.Diff([]string{"John Doe", "Jane Roe"}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestZeroTime(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.zero_time_table (id int PRIMARY KEY, created timestamp, updated timestamp)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type Row struct {
+		ID      int
+		Created time.Time
+		Updated time.Time
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	stmt, names := qb.Insert("gocqlx_test.zero_time_table").Columns("id", "created", "updated").ToCql()
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(Row{ID: 1, Created: now, Updated: now}).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		if err := gocqlx.Query(session.Query(stmt), names).ZeroTimeAsUnset().BindStruct(Row{ID: 1, Created: now}).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+		var updated *time.Time
+		if err := session.Query(`SELECT updated FROM gocqlx_test.zero_time_table WHERE id = 1`).Scan(&updated); err != nil {
+			t.Fatal("select:", err)
+		}
+		if updated == nil || !updated.Equal(now) {
+			t.Fatal("expected updated to be left untouched got", updated)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		if err := gocqlx.Query(session.Query(stmt), names).ZeroTimeAsNull().BindStruct(Row{ID: 1, Created: now}).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+		var updated *time.Time
+		if err := session.Query(`SELECT updated FROM gocqlx_test.zero_time_table WHERE id = 1`).Scan(&updated); err != nil {
+			t.Fatal("select:", err)
+		}
+		if updated != nil {
+			t.Fatal("expected null got", updated)
+		}
+	})
+}
+
+func TestColumnNamesAndTypes(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.column_types_table (id int PRIMARY KEY, name text, tags set<text>, props map<text, bigint>)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	iter := gocqlx.Iter(session.Query(`SELECT id, name AS n, tags, props FROM gocqlx_test.column_types_table`))
+	defer iter.Close()
+
+	if diff := cmp.Diff([]string{"id", "n", "tags", "props"}, iter.ColumnNames()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	types := iter.ColumnTypes()
+	golden := map[string]string{
+		"id":    "int",
+		"n":     "varchar",
+		"tags":  "set(varchar)",
+		"props": "map(varchar, bigint)",
+	}
+	if len(types) != len(golden) {
+		t.Fatal("expected", len(golden), "types got", types)
+	}
+	for name, typ := range golden {
+		info, ok := types[name]
+		if !ok {
+			t.Fatal("missing type for", name)
+		}
+		if s := fmt.Sprint(info); s != typ {
+			t.Error(name, "expected", typ, "got", s)
+		}
+	}
+}
+
+// RowPerson implements gocqlx.RowUnmarshaler.
+type RowPerson struct {
+	ID    int
+	Name  string
+	Email *string
+}
+
+func (p *RowPerson) UnmarshalRow(columns []gocql.ColumnInfo, values [][]byte) error {
+	for i, c := range columns {
+		var err error
+		switch c.Name {
+		case "id":
+			err = gocql.Unmarshal(c.TypeInfo, values[i], &p.ID)
+		case "name":
+			err = gocql.Unmarshal(c.TypeInfo, values[i], &p.Name)
+			p.Name = strings.ToUpper(p.Name)
+		case "email":
+			if values[i] != nil {
+				p.Email = new(string)
+				err = gocql.Unmarshal(c.TypeInfo, values[i], p.Email)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRowUnmarshaler(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.row_unmarshaler_table (k int, id int, name text, email text, PRIMARY KEY (k, id))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.Query(`INSERT INTO gocqlx_test.row_unmarshaler_table (k, id, name, email) VALUES (1, 1, 'foo', 'foo@example.com')`).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+	if err := session.Query(`INSERT INTO gocqlx_test.row_unmarshaler_table (k, id, name) VALUES (1, 2, 'bar')`).Exec(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	email := "foo@example.com"
+	golden := []RowPerson{{1, "FOO", &email}, {2, "BAR", nil}}
+
+	const stmt = `SELECT id, name, email FROM gocqlx_test.row_unmarshaler_table WHERE k = 1`
+
+	t.Run("get", func(t *testing.T) {
+		var v RowPerson
+		if err := gocqlx.Iter(session.Query(stmt)).Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(golden[0], v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("select", func(t *testing.T) {
+		var v []RowPerson
+		if err := gocqlx.Iter(session.Query(stmt).PageSize(1)).Select(&v); err != nil {
+			t.Fatal("select:", err)
+		}
+		if diff := cmp.Diff(golden, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("select ptr", func(t *testing.T) {
+		var v []*RowPerson
+		if err := gocqlx.Iter(session.Query(stmt)).Select(&v); err != nil {
+			t.Fatal("select:", err)
+		}
+		if len(v) != 2 || v[1].Name != "BAR" {
+			t.Fatal("unexpected result", v)
+		}
+	})
+
+	t.Run("extra columns are passed through", func(t *testing.T) {
+		var v RowPerson
+		if err := gocqlx.Iter(session.Query(`SELECT * FROM gocqlx_test.row_unmarshaler_table WHERE k = 1`)).Get(&v); err != nil {
+			t.Fatal("get:", err)
+		}
+		if diff := cmp.Diff(golden[0], v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestMultipleMapColumns(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.multiple_map_table (id int PRIMARY KEY, labels map<text, text>, counts map<text, int>, scores map<int, double>)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type Row struct {
+		ID     int
+		Labels map[string]string
+		Counts map[string]int
+		Scores map[int]float64
+	}
+
+	golden := []Row{
+		{
+			ID:     1,
+			Labels: map[string]string{"a": "foo", "b": "bar"},
+			Counts: map[string]int{"a": 1, "b": 2, "c": 3},
+			Scores: map[int]float64{1: 0.5},
+		},
+		{
+			ID:     2,
+			Labels: map[string]string{"c": "baz"},
+		},
+	}
+
+	stmt, names := qb.Insert("gocqlx_test.multiple_map_table").Columns("id", "labels", "counts", "scores").ToCql()
+	for _, r := range golden {
+		if err := gocqlx.Query(session.Query(stmt), names).BindStruct(r).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	const query = `SELECT * FROM gocqlx_test.multiple_map_table WHERE id IN (1, 2)`
+
+	t.Run("select", func(t *testing.T) {
+		var v []Row
+		if err := gocqlx.Iter(session.Query(query)).Select(&v); err != nil {
+			t.Fatal("select:", err)
+		}
+		if diff := cmp.Diff(golden, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("struct scan reuse", func(t *testing.T) {
+		var (
+			v   Row
+			out []Row
+		)
+		iter := gocqlx.Iter(session.Query(query))
+		for iter.StructScan(&v) {
+			out = append(out, v)
+		}
+		if err := iter.Close(); err != nil {
+			t.Fatal("close:", err)
+		}
+		if diff := cmp.Diff(golden, out); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+type Color int
+
+const (
+	Red Color = iota + 1
+	Green
+)
+
+type Status string
+
+func TestEnum(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.enum_table (id int PRIMARY KEY, color int, colors list<int>, status text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type EnumTable struct {
+		ID     int
+		Color  Color
+		Colors []Color
+		Status Status
+	}
+
+	m := EnumTable{
+		ID:     1,
+		Color:  Green,
+		Colors: []Color{Red, Green},
+		Status: "active",
+	}
+
+	stmt, names := qb.Insert("gocqlx_test.enum_table").Columns("id", "color", "colors", "status").ToCql()
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(m).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	stmt, names = qb.Select("gocqlx_test.enum_table").Where(qb.Eq("id")).ToCql()
+	var v EnumTable
+	if err := gocqlx.Query(session.Query(stmt), names).BindStruct(m).GetRelease(&v); err != nil {
+		t.Fatal("get:", err)
+	}
+	if diff := cmp.Diff(m, v); diff != "" {
+		t.Fatal(diff)
+	}
+
+	stmt, names = qb.Select("gocqlx_test.enum_table").Columns("id").Where(qb.Eq("color")).AllowFiltering().ToCql()
+	var ids []int
+	if err := gocqlx.Query(session.Query(stmt), names).BindMap(qb.M{"color": Green}).SelectRelease(&ids); err != nil {
+		t.Fatal("select:", err)
+	}
+	if diff := cmp.Diff([]int{1}, ids); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestSelectContext(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	if err := ExecStmt(session, `CREATE TABLE gocqlx_test.select_context_table (k int, c int, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := session.Query(`INSERT INTO select_context_table (k, c) values (?, ?)`, 1, i).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	t.Run("select", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var v []int
+		err := gocqlx.Iter(session.Query(`SELECT c FROM select_context_table WHERE k=1`).PageSize(2)).SelectContext(ctx, &v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(v) != 5 {
+			t.Fatal("expected 5 rows got", len(v))
+		}
+	})
+
+	t.Run("select canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var v []int
+		err := gocqlx.Iter(session.Query(`SELECT c FROM select_context_table WHERE k=1`).PageSize(2)).SelectContext(ctx, &v)
+		if err != context.Canceled {
+			t.Fatal("expected context canceled got", err)
+		}
+		if diff := cmp.Diff([]int{0, 1}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("get canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var v int
+		err := gocqlx.Iter(session.Query(`SELECT c FROM select_context_table WHERE k=1`)).GetContext(ctx, &v)
+		if err != context.Canceled {
+			t.Fatal("expected context canceled got", err)
+		}
+	})
+}
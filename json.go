@@ -0,0 +1,266 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gocql/gocql"
+)
+
+// RowJSON scans the next row and returns it encoded as a JSON object keyed by
+// column name, fields are in the order of the result columns. Values are
+// decoded to the Go types matching the column types as reported by
+// gocql.TypeInfo.New, collections are encoded as arrays and objects, UDTs as
+// nested objects and nulls as JSON null. Custom types are encoded as raw bytes
+// and maps with keys that are not scalars i.e. UDTs or tuples as arrays of
+// key value pairs.
+//
+// When there are no more rows RowJSON closes the iterator and returns nil and
+// any error that happened during the query or the iteration.
+func (iter *Iterx) RowJSON() ([]byte, error) {
+	values, ok := iter.scanRowValues()
+	if !ok {
+		return nil, iter.Close()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, c := range iter.Columns() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(c.Name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		v, err := json.Marshal(values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// scanRowValues scans the next row into values of Go types matching
+// the column types, null values are returned as nil.
+func (iter *Iterx) scanRowValues() ([]interface{}, bool) {
+	columns := iter.Columns()
+	values := make([]rowValue, len(columns))
+	dests := make([]interface{}, len(columns))
+	for i := range values {
+		dests[i] = &values[i]
+	}
+
+	if !iter.Scan(dests...) {
+		return nil, false
+	}
+
+	r := make([]interface{}, len(values))
+	for i := range values {
+		r[i] = values[i].value
+	}
+	return r, true
+}
+
+// rowValue unmarshals a column into a new value of the Go type matching
+// the column type. Values of types that gocql.TypeInfo.New cannot build are
+// decoded by decodeValue.
+type rowValue struct {
+	value interface{}
+}
+
+func (v *rowValue) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if data == nil {
+		v.value = nil
+		return nil
+	}
+	if !newable(info) {
+		var err error
+		v.value, err = decodeValue(info, data)
+		return err
+	}
+
+	p := info.New()
+	if err := gocql.Unmarshal(info, data, p); err != nil {
+		return err
+	}
+	v.value = reflect.ValueOf(p).Elem().Interface()
+	return nil
+}
+
+// newable returns true if a value of type info can be built with
+// gocql.TypeInfo.New and unmarshalled by gocql. Custom types have no Go type
+// and map keys must be comparable.
+func newable(info gocql.TypeInfo) bool {
+	switch t := info.(type) {
+	case gocql.CollectionType:
+		if t.Type() == gocql.TypeMap {
+			return newable(t.Key) && reflect.TypeOf(t.Key.New()).Elem().Comparable() && newable(t.Elem)
+		}
+		return newable(t.Elem)
+	case gocql.TupleTypeInfo:
+		for _, e := range t.Elems {
+			if !newable(e) {
+				return false
+			}
+		}
+		return true
+	case gocql.UDTTypeInfo:
+		for _, e := range t.Elements {
+			if !newable(e.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return info.Type() != gocql.TypeCustom
+}
+
+// decodeValue decodes a value of type info that is not newable. Custom types
+// are returned as raw bytes, lists, sets and tuples as []interface{}, UDTs as
+// map[string]interface{} and maps as map[string]interface{} keyed by the
+// string form of the keys or, if the keys are not scalars, as []interface{}
+// of key value pairs.
+func decodeValue(info gocql.TypeInfo, data []byte) (interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var r rowValue
+	if newable(info) {
+		err := r.UnmarshalCQL(info, data)
+		return r.value, err
+	}
+
+	switch t := info.(type) {
+	case gocql.CollectionType:
+		d := cqlDecoder{data: data, proto: t.Version()}
+		n, err := d.size()
+		if err != nil {
+			return nil, err
+		}
+		if t.Type() != gocql.TypeMap {
+			out := make([]interface{}, n)
+			for i := range out {
+				if out[i], err = d.value(t.Elem); err != nil {
+					return nil, err
+				}
+			}
+			return out, nil
+		}
+
+		var (
+			m     = make(map[string]interface{}, n)
+			pairs = make([]interface{}, 0, n)
+		)
+		for i := 0; i < n; i++ {
+			k, err := d.value(t.Key)
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.value(t.Elem)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = v
+			pairs = append(pairs, []interface{}{k, v})
+		}
+		if scalarKey(t.Key) {
+			return m, nil
+		}
+		return pairs, nil
+	case gocql.TupleTypeInfo:
+		d := cqlDecoder{data: data, proto: 4}
+		out := make([]interface{}, len(t.Elems))
+		for i, e := range t.Elems {
+			var err error
+			if out[i], err = d.value(e); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case gocql.UDTTypeInfo:
+		d := cqlDecoder{data: data, proto: 4}
+		out := make(map[string]interface{}, len(t.Elements))
+		for _, e := range t.Elements {
+			// fields added to the type after the value was written are
+			// missing
+			if len(d.data) == 0 {
+				out[e.Name] = nil
+				continue
+			}
+			v, err := d.value(e.Type)
+			if err != nil {
+				return nil, err
+			}
+			out[e.Name] = v
+		}
+		return out, nil
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+// scalarKey returns true if map keys of type info can be represented as
+// strings.
+func scalarKey(info gocql.TypeInfo) bool {
+	switch info.Type() {
+	case gocql.TypeCustom, gocql.TypeBlob, gocql.TypeList, gocql.TypeSet, gocql.TypeMap, gocql.TypeUDT, gocql.TypeTuple:
+		return false
+	}
+	return true
+}
+
+// cqlDecoder reads length prefixed values of collections, tuples and UDTs.
+// Collection sizes and lengths are 2 bytes long in protocol versions 1 and 2
+// and 4 bytes long otherwise, tuples and UDTs always use 4 bytes.
+type cqlDecoder struct {
+	data  []byte
+	proto byte
+}
+
+func (d *cqlDecoder) size() (int, error) {
+	if d.proto > 2 {
+		if len(d.data) < 4 {
+			return 0, errors.New("unexpected end of data")
+		}
+		n := int(int32(binary.BigEndian.Uint32(d.data)))
+		d.data = d.data[4:]
+		return n, nil
+	}
+	if len(d.data) < 2 {
+		return 0, errors.New("unexpected end of data")
+	}
+	n := int(binary.BigEndian.Uint16(d.data))
+	d.data = d.data[2:]
+	return n, nil
+}
+
+func (d *cqlDecoder) value(info gocql.TypeInfo) (interface{}, error) {
+	n, err := d.size()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, nil
+	}
+	if len(d.data) < n {
+		return nil, errors.New("unexpected end of data")
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return decodeValue(info, b)
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+// cqlBytes encodes values as 4 bytes length prefixed bytes, nil is encoded
+// as null.
+func cqlBytes(values ...[]byte) []byte {
+	var out []byte
+	for _, v := range values {
+		n := make([]byte, 4)
+		if v == nil {
+			binary.BigEndian.PutUint32(n, 0xffffffff)
+		} else {
+			binary.BigEndian.PutUint32(n, uint32(len(v)))
+		}
+		out = append(out, n...)
+		out = append(out, v...)
+	}
+	return out
+}
+
+func cqlSize(n int) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(n))
+	return b
+}
+
+func TestRowValueUnmarshalCQL(t *testing.T) {
+	var (
+		text   = gocql.NewNativeType(4, gocql.TypeText, "")
+		intT   = gocql.NewNativeType(4, gocql.TypeInt, "")
+		custom = gocql.NewNativeType(4, gocql.TypeCustom, "com.example.Point")
+		udt    = gocql.UDTTypeInfo{
+			NativeType: gocql.NewNativeType(4, gocql.TypeUDT, ""),
+			Name:       "point",
+			Elements: []gocql.UDTField{
+				{Name: "x", Type: intT},
+				{Name: "label", Type: custom},
+			},
+		}
+		tuple = gocql.TupleTypeInfo{
+			NativeType: gocql.NewNativeType(4, gocql.TypeTuple, ""),
+			Elems:      []gocql.TypeInfo{intT, text},
+		}
+	)
+
+	marshal := func(info gocql.TypeInfo, v interface{}) []byte {
+		b, err := gocql.Marshal(info, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	udtValue := cqlBytes(marshal(intT, 1), []byte{0xca, 0xfe})
+	tupleValue := cqlBytes(marshal(intT, 2), marshal(text, "b"))
+
+	table := []struct {
+		Name  string
+		Info  gocql.TypeInfo
+		Data  []byte
+		Value interface{}
+	}{
+		{
+			Name:  "newable",
+			Info:  gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeList, ""), Elem: text},
+			Data:  marshal(gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeList, ""), Elem: text}, []string{"a", "b"}),
+			Value: []string{"a", "b"},
+		},
+		{
+			Name:  "custom",
+			Info:  custom,
+			Data:  []byte{0xca, 0xfe},
+			Value: []byte{0xca, 0xfe},
+		},
+		{
+			Name:  "null custom",
+			Info:  custom,
+			Data:  nil,
+			Value: nil,
+		},
+		{
+			Name:  "udt with custom field",
+			Info:  udt,
+			Data:  udtValue,
+			Value: map[string]interface{}{"x": 1, "label": []byte{0xca, 0xfe}},
+		},
+		{
+			Name:  "udt with missing field",
+			Info:  udt,
+			Data:  cqlBytes(marshal(intT, 1)),
+			Value: map[string]interface{}{"x": 1, "label": nil},
+		},
+		{
+			Name:  "list of custom",
+			Info:  gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeList, ""), Elem: custom},
+			Data:  append(cqlSize(2), cqlBytes([]byte{0x01}, nil)...),
+			Value: []interface{}{[]byte{0x01}, nil},
+		},
+		{
+			Name:  "map with custom values",
+			Info:  gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeMap, ""), Key: intT, Elem: custom},
+			Data:  append(cqlSize(1), cqlBytes(marshal(intT, 7), []byte{0x01})...),
+			Value: map[string]interface{}{"7": []byte{0x01}},
+		},
+		{
+			Name:  "map with udt keys",
+			Info:  gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeMap, ""), Key: udt, Elem: text},
+			Data:  append(cqlSize(1), cqlBytes(udtValue, marshal(text, "a"))...),
+			Value: []interface{}{[]interface{}{map[string]interface{}{"x": 1, "label": []byte{0xca, 0xfe}}, "a"}},
+		},
+		{
+			Name:  "map with tuple keys",
+			Info:  gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeMap, ""), Key: tuple, Elem: intT},
+			Data:  append(cqlSize(1), cqlBytes(tupleValue, marshal(intT, 3))...),
+			Value: []interface{}{[]interface{}{[]interface{}{2, "b"}, 3}},
+		},
+		{
+			Name:  "map with blob keys",
+			Info:  gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeMap, ""), Key: gocql.NewNativeType(4, gocql.TypeBlob, ""), Elem: intT},
+			Data:  append(cqlSize(1), cqlBytes([]byte{0x01}, marshal(intT, 3))...),
+			Value: []interface{}{[]interface{}{[]byte{0x01}, 3}},
+		},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			var v rowValue
+			if err := v.UnmarshalCQL(test.Info, test.Data); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Value, v.value); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		var v rowValue
+		if err := v.UnmarshalCQL(udt, udtValue[:6]); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
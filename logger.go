@@ -0,0 +1,34 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// QueryLogger is a function called after a query is executed with the query
+// statement, bind names, execution error and duration.
+type QueryLogger func(ctx context.Context, stmt string, names []string, err error, d time.Duration)
+
+// queryLogger adapts QueryLogger to gocql.QueryObserver.
+type queryLogger struct {
+	log   QueryLogger
+	names []string
+}
+
+func (l queryLogger) ObserveQuery(ctx context.Context, q gocql.ObservedQuery) {
+	l.log(ctx, q.Statement, l.names, q.Err, q.End.Sub(q.Start))
+}
+
+// WithQueryLogger returns a copy of the session that calls log after every
+// execution attempt of the queries created by the session. It's implemented
+// as gocql.QueryObserver, setting Observer on a query replaces the logger.
+func (s Session) WithQueryLogger(log QueryLogger) Session {
+	s.logger = log
+	return s
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQueryLogger(t *testing.T) {
+	type entry struct {
+		Ctx   context.Context
+		Stmt  string
+		Names []string
+		Err   error
+		D     time.Duration
+	}
+
+	var got []entry
+	log := func(ctx context.Context, stmt string, names []string, err error, d time.Duration) {
+		got = append(got, entry{ctx, stmt, names, err, d})
+	}
+
+	type key struct{}
+
+	var (
+		ctx   = context.WithValue(context.Background(), key{}, "value")
+		start = time.Now()
+		err   = errors.New("error")
+	)
+	l := queryLogger{log: log, names: []string{"id"}}
+	l.ObserveQuery(ctx, gocql.ObservedQuery{
+		Statement: "SELECT * FROM table WHERE id=?",
+		Start:     start,
+		End:       start.Add(time.Second),
+		Err:       err,
+	})
+
+	if len(got) != 1 {
+		t.Fatal("expected 1 log entry got", len(got))
+	}
+	e := got[0]
+	if e.Ctx != ctx || e.Err != err {
+		t.Fatal("expected context and error to be passed")
+	}
+	if diff := cmp.Diff("SELECT * FROM table WHERE id=?", e.Stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"id"}, e.Names); diff != "" {
+		t.Error(diff)
+	}
+	if e.D != time.Second {
+		t.Error("expected 1s got", e.D)
+	}
+}
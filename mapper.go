@@ -5,11 +5,158 @@
 package gocqlx
 
 import (
+	"reflect"
+	"strings"
+	"sync"
+
 	"github.com/scylladb/go-reflectx"
 )
 
 // DefaultMapper uses `db` tag and automatically converts struct field names to
 // snake case. It can be set to whatever you want, but it is encouraged to be
 // set before gocqlx is used as name-to-field mappings are cached after first
-// use on a type.
-var DefaultMapper = reflectx.NewMapperFunc("db", reflectx.CamelToSnakeASCII)
+// use on a type. Assigning DefaultMapper directly while queries are running is
+// a data race, use SetDefaultMapper or WithDefaultMapper instead.
+var DefaultMapper = newDefaultMapper()
+
+func newDefaultMapper() *reflectx.Mapper {
+	return reflectx.NewMapperFunc("db", reflectx.CamelToSnakeASCII)
+}
+
+var (
+	defaultMapperMu sync.RWMutex
+	scopedMapperMu  sync.Mutex
+)
+
+// defaultMapper returns DefaultMapper, it's synchronized with SetDefaultMapper.
+func defaultMapper() *reflectx.Mapper {
+	defaultMapperMu.RLock()
+	defer defaultMapperMu.RUnlock()
+	return DefaultMapper
+}
+
+// SetDefaultMapper sets DefaultMapper to m and returns the previous mapper.
+// If m is nil the DefaultMapper is reset to a new mapper using `db` tag and
+// snake case names.
+func SetDefaultMapper(m *reflectx.Mapper) *reflectx.Mapper {
+	if m == nil {
+		m = newDefaultMapper()
+	}
+
+	defaultMapperMu.Lock()
+	prev := DefaultMapper
+	DefaultMapper = m
+	defaultMapperMu.Unlock()
+
+	return prev
+}
+
+// WithDefaultMapper sets DefaultMapper to m for the duration of f, the
+// previous mapper is restored when f returns or panics. Calls to
+// WithDefaultMapper are serialized, so that tests swapping the mapper do not
+// observe each other's mappers. Calls cannot be nested, calling
+// WithDefaultMapper from f deadlocks, SetDefaultMapper can be used instead.
+func WithDefaultMapper(m *reflectx.Mapper, f func()) {
+	scopedMapperMu.Lock()
+	defer scopedMapperMu.Unlock()
+
+	prev := SetDefaultMapper(m)
+	defer SetDefaultMapper(prev)
+
+	f()
+}
+
+// nestedNames returns names with `_` separated prefixes replaced by paths of
+// nested struct fields of t, i.e. address_city is replaced by address.city.
+// Names that match a field directly are left as is.
+func nestedNames(m *reflectx.Mapper, t reflect.Type, names []string) []string {
+	tm := m.TypeMap(reflectx.Deref(t))
+
+	var paths map[string]string
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = name
+		if _, ok := tm.Names[name]; ok {
+			continue
+		}
+		if paths == nil {
+			paths = make(map[string]string)
+			for path := range tm.Names {
+				if strings.Contains(path, ".") {
+					paths[strings.ReplaceAll(path, ".", "_")] = path
+				}
+			}
+		}
+		if path, ok := paths[name]; ok {
+			out[i] = path
+		}
+	}
+	return out
+}
+
+// foldedNames returns names with names that do not match a field of t
+// replaced by the field name they match ignoring case and underscores, i.e.
+// FIRST_NAME is replaced by first_name. Names that match a field directly are
+// left as is, so are names matching more than one field.
+func foldedNames(m *reflectx.Mapper, t reflect.Type, names []string) []string {
+	tm := m.TypeMap(reflectx.Deref(t))
+
+	var folded map[string]string
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = name
+		if _, ok := tm.Names[name]; ok {
+			continue
+		}
+		if folded == nil {
+			folded = make(map[string]string)
+			for path := range tm.Names {
+				k := foldName(path)
+				if _, ok := folded[k]; ok {
+					folded[k] = ""
+				} else {
+					folded[k] = path
+				}
+			}
+		}
+		if path := folded[foldName(name)]; path != "" {
+			out[i] = path
+		}
+	}
+	return out
+}
+
+func foldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// FieldFilter decides if struct field f takes part in binding and scanning,
+// fields for which it returns false are treated as if they were tagged with
+// `db:"-"`. It's called for every field on the path to a nested field.
+type FieldFilter func(f reflect.StructField) bool
+
+// filterTraversals clears traversals of struct type t that go through fields
+// rejected by filter.
+func filterTraversals(t reflect.Type, traversals [][]int, filter FieldFilter) [][]int {
+	if filter == nil {
+		return traversals
+	}
+	t = reflectx.Deref(t)
+	for i, traversal := range traversals {
+		if len(traversal) != 0 && !allowedTraversal(t, traversal, filter) {
+			traversals[i] = nil
+		}
+	}
+	return traversals
+}
+
+func allowedTraversal(t reflect.Type, traversal []int, filter FieldFilter) bool {
+	for _, i := range traversal {
+		f := t.Field(i)
+		if !filter(f) {
+			return false
+		}
+		t = reflectx.Deref(f.Type)
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/go-reflectx"
+)
+
+func TestNestedNames(t *testing.T) {
+	type Address struct {
+		City    string
+		Zip     string
+		ZipCode string
+	}
+	type Person struct {
+		Name        string
+		AddressCity string
+		Address     Address
+		Billing     *Address `db:"bill"`
+	}
+
+	names := nestedNames(DefaultMapper, reflect.TypeOf(&Person{}), []string{
+		"name",
+		"address_city",
+		"address_zip",
+		"address_zip_code",
+		"bill_city",
+		"address",
+		"unknown_city",
+	})
+	golden := []string{
+		"name",
+		"address_city",
+		"address.zip",
+		"address.zip_code",
+		"bill.city",
+		"address",
+		"unknown_city",
+	}
+	if diff := cmp.Diff(golden, names); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestFoldedNames(t *testing.T) {
+	type Person struct {
+		ID        int
+		FirstName string
+		UserID    int `db:"user_id"`
+		Userid    int `db:"userid"`
+	}
+
+	names := foldedNames(DefaultMapper, reflect.TypeOf(&Person{}), []string{
+		"id",
+		"FirstName",
+		"first_name",
+		"FIRST_NAME",
+		"UserID",
+		"userid",
+		"last_name",
+	})
+	golden := []string{
+		"id",
+		"first_name",
+		"first_name",
+		"first_name",
+		"UserID",
+		"userid",
+		"last_name",
+	}
+	if diff := cmp.Diff(golden, names); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestFilterTraversals(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string `skip:"true"`
+	}
+	type Person struct {
+		Name    string
+		Secret  string `skip:"true"`
+		Address Address
+		Billing *Address `db:"bill" skip:"true"`
+	}
+
+	typ := reflect.TypeOf(&Person{})
+	names := []string{"name", "secret", "address.city", "address.zip", "bill.city"}
+	filter := func(f reflect.StructField) bool {
+		return f.Tag.Get("skip") == ""
+	}
+
+	traversals := filterTraversals(typ, DefaultMapper.TraversalsByName(typ, names), filter)
+	golden := [][]int{{0}, nil, {2, 0}, nil, nil}
+	if diff := cmp.Diff(golden, traversals); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestWithDefaultMapper(t *testing.T) {
+	orig := DefaultMapper
+
+	t.Run("restore", func(t *testing.T) {
+		m := reflectx.NewMapper("json")
+		WithDefaultMapper(m, func() {
+			if DefaultMapper != m {
+				t.Fatal("expected mapper to be set")
+			}
+		})
+		if DefaultMapper != orig {
+			t.Fatal("expected mapper to be restored")
+		}
+	})
+
+	t.Run("restore on panic", func(t *testing.T) {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected panic")
+				}
+			}()
+			WithDefaultMapper(reflectx.NewMapper("json"), func() {
+				panic("boom")
+			})
+		}()
+		if DefaultMapper != orig {
+			t.Fatal("expected mapper to be restored")
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		m := reflectx.NewMapper("json")
+		WithDefaultMapper(m, func() {
+			prev := SetDefaultMapper(nil)
+			if prev != m {
+				t.Fatal("expected previous mapper to be returned")
+			}
+			if DefaultMapper == nil || DefaultMapper == m || DefaultMapper == orig {
+				t.Fatal("expected new default mapper")
+			}
+		})
+		if DefaultMapper != orig {
+			t.Fatal("expected mapper to be restored")
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m := reflectx.NewMapper("json")
+				WithDefaultMapper(m, func() {
+					if DefaultMapper != m {
+						t.Error("unexpected mapper")
+					}
+				})
+			}()
+		}
+		wg.Wait()
+		if DefaultMapper != orig {
+			t.Fatal("expected mapper to be restored")
+		}
+	})
+
+	t.Run("concurrent readers", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				WithDefaultMapper(reflectx.NewMapper("json"), func() {})
+			}()
+			go func() {
+				defer wg.Done()
+				if q := Query(&gocql.Query{}, nil); q.Mapper == nil {
+					t.Error("expected mapper")
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/table"
+)
+
+// TableSchema is a table schema read from the driver metadata.
+type TableSchema struct {
+	// Metadata can be passed to table.New to build CRUD queries.
+	Metadata table.Metadata
+	// Types maps column names to column types.
+	Types map[string]gocql.TypeInfo
+}
+
+// TableMetadata returns schema of the table based on gocql KeyspaceMetadata,
+// it allows to build generic CRUD layers without maintaining column lists by
+// hand. Columns are ordered as in the driver metadata, partition key columns
+// first.
+func TableMetadata(session *gocql.Session, keyspace, name string) (TableSchema, error) {
+	km, err := session.KeyspaceMetadata(keyspace)
+	if err != nil {
+		return TableSchema{}, err
+	}
+	tm, ok := km.Tables[name]
+	if !ok {
+		return TableSchema{}, fmt.Errorf("table %s.%s does not exist", keyspace, name)
+	}
+
+	s := TableSchema{
+		Metadata: table.Metadata{
+			Name: keyspace + "." + name,
+		},
+		Types: make(map[string]gocql.TypeInfo, len(tm.Columns)),
+	}
+	for _, c := range tm.PartitionKey {
+		s.Metadata.PartKey = append(s.Metadata.PartKey, c.Name)
+	}
+	for _, c := range tm.ClusteringColumns {
+		s.Metadata.SortKey = append(s.Metadata.SortKey, c.Name)
+	}
+	for _, n := range tm.OrderedColumns {
+		c := tm.Columns[n]
+		s.Metadata.Columns = append(s.Metadata.Columns, n)
+		if c.Kind == gocql.ColumnStatic {
+			s.Metadata.Static = append(s.Metadata.Static, n)
+		}
+		s.Types[n] = c.Type
+	}
+
+	return s, nil
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scylladb/go-reflectx"
+	"github.com/scylladb/gocqlx/qb"
+)
+
+// Read pairs a query builder with the destination the query result is
+// selected into, see Session.SelectMany.
+type Read struct {
+	Builder qb.Builder
+	Dest    interface{}
+}
+
+// SelectMany executes reads one by one binding all of them with arg, and
+// selects the results into the read destinations. It's meant for reading
+// from several tables that share a partition key, all the reads are
+// executed, errors are combined into a single error that lists the failed
+// reads.
+func (s Session) SelectMany(arg interface{}, reads ...Read) error {
+	errs := make([]error, len(reads))
+	for i := range reads {
+		errs[i] = s.selectRead(arg, reads[i])
+	}
+	return readsError(errs)
+}
+
+// SelectManyConcurrent is like SelectMany but the reads are executed
+// concurrently.
+func (s Session) SelectManyConcurrent(arg interface{}, reads ...Read) error {
+	errs := make([]error, len(reads))
+
+	var wg sync.WaitGroup
+	wg.Add(len(reads))
+	for i := range reads {
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.selectRead(arg, reads[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return readsError(errs)
+}
+
+func (s Session) selectRead(arg interface{}, r Read) error {
+	return s.Query(r.Builder.ToCql()).BindStruct(arg).SelectRelease(r.Dest)
+}
+
+func readsError(errs []error) error {
+	var msgs []string
+	for i, err := range errs {
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("read %d failed: %s", i+1, err))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// SelectOrConcurrency is the maximal number of queries executed at the same
+// time by SelectOr, zero or a negative value means no limit.
+var SelectOrConcurrency = 16
+
+// SelectOr emulates OR, that CQL lacks, by executing the builder query once
+// for every alternative of bind values in args, concurrently, at most
+// SelectOrConcurrency queries at a time. Each arg is
+// either a map (qb.M) or a struct bound with BindStruct. The results are
+// merged in the order of args into dest, which must be a pointer to slice of
+// structs, rows with the same values of the key columns, usually the primary
+// key, are added only once. If key is empty all the rows are added.
+func (s Session) SelectOr(builder *qb.SelectBuilder, args []interface{}, dest interface{}, key ...string) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("expected a pointer but got %T", dest)
+	}
+	slice, err := baseType(value.Type(), reflect.Slice)
+	if err != nil {
+		return err
+	}
+
+	var index [][]int
+	if len(key) > 0 {
+		m := s.Mapper.TypeMap(reflectx.Deref(slice.Elem()))
+		for _, k := range key {
+			fi, ok := m.Names[k]
+			if !ok {
+				return fmt.Errorf("missing key field %q in %s", k, slice.Elem())
+			}
+			index = append(index, fi.Index)
+		}
+	}
+
+	stmt, names := builder.ToCql()
+	results := make([]reflect.Value, len(args))
+	errs := make([]error, len(args))
+
+	limit := SelectOrConcurrency
+	if limit <= 0 || limit > len(args) {
+		limit = len(args)
+	}
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	wg.Add(len(args))
+	for i := range args {
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = reflect.New(slice)
+			q := s.Query(stmt, names)
+			if m, ok := args[i].(qb.M); ok {
+				q.BindMap(m)
+			} else if m, ok := args[i].(map[string]interface{}); ok {
+				q.BindMap(m)
+			} else {
+				q.BindStruct(args[i])
+			}
+			errs[i] = q.SelectRelease(results[i].Interface())
+		}(i)
+	}
+	wg.Wait()
+
+	if err := readsError(errs); err != nil {
+		return err
+	}
+
+	v := reflect.MakeSlice(slice, 0, 0)
+	seen := make(map[string]struct{})
+	for _, r := range results {
+		rows := r.Elem()
+		for i := 0; i < rows.Len(); i++ {
+			row := rows.Index(i)
+			if len(index) > 0 {
+				k := rowKey(reflect.Indirect(row), index)
+				if _, ok := seen[k]; ok {
+					continue
+				}
+				seen[k] = struct{}{}
+			}
+			v = reflect.Append(v, row)
+		}
+	}
+	reflect.Indirect(value).Set(v)
+
+	return nil
+}
+
+// rowKey returns a string representation of the fields of v at index,
+// pointers are dereferenced so that equal values have equal keys.
+func rowKey(v reflect.Value, index [][]int) string {
+	var b strings.Builder
+	for _, i := range index {
+		f := reflectx.FieldByIndexesReadOnly(v, i)
+		for f.Kind() == reflect.Ptr && !f.IsNil() {
+			f = f.Elem()
+		}
+		switch {
+		case f.Kind() == reflect.Ptr:
+			b.WriteString("nil")
+		case f.Type() == timeType:
+			// time.Time carries a location pointer, compare instants only
+			fmt.Fprintf(&b, "time(%d)", f.Interface().(time.Time).UnixNano())
+		default:
+			fmt.Fprintf(&b, "%#v", f.Interface())
+		}
+		b.WriteByte(0)
+	}
+	return b.String()
+}
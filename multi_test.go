@@ -0,0 +1,64 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReadsError(t *testing.T) {
+	if err := readsError([]error{nil, nil}); err != nil {
+		t.Fatal("expected nil got", err)
+	}
+
+	err := readsError([]error{errors.New("a"), nil, errors.New("c")})
+	if err == nil || err.Error() != "read 1 failed: a; read 3 failed: c" {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestRowKey(t *testing.T) {
+	type Row struct {
+		K int
+		C string
+		V string
+	}
+	index := [][]int{{0}, {1}}
+
+	a := rowKey(reflect.ValueOf(Row{1, "a", "x"}), index)
+	b := rowKey(reflect.ValueOf(Row{1, "a", "y"}), index)
+	c := rowKey(reflect.ValueOf(Row{1, "b", "x"}), index)
+	if a != b {
+		t.Error("expected equal keys", a, b)
+	}
+	if a == c {
+		t.Error("expected different keys", a, c)
+	}
+}
+
+func TestRowKeyDeref(t *testing.T) {
+	type Row struct {
+		K *int
+		T time.Time
+	}
+	index := [][]int{{0}, {1}}
+
+	one, otherOne, two := 1, 1, 2
+	ts := time.Date(2020, 2, 3, 10, 20, 30, 0, time.UTC)
+
+	a := rowKey(reflect.ValueOf(Row{&one, ts}), index)
+	b := rowKey(reflect.ValueOf(Row{&otherOne, ts.In(time.FixedZone("", 3600))}), index)
+	c := rowKey(reflect.ValueOf(Row{&two, ts}), index)
+	d := rowKey(reflect.ValueOf(Row{nil, ts}), index)
+	if a != b {
+		t.Error("expected equal keys", a, b)
+	}
+	if a == c || a == d {
+		t.Error("expected different keys", a, c, d)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"sync"
+)
+
+// namedCache memoizes named queries compiled with CompileNamedQuery.
+type namedCache struct {
+	mu sync.RWMutex
+	m  map[string]*namedQuery
+}
+
+type namedQuery struct {
+	query string
+	stmt  string
+	names []string
+}
+
+func newNamedCache() *namedCache {
+	return &namedCache{
+		m: make(map[string]*namedQuery),
+	}
+}
+
+// get returns compiled query for key, query is compiled if it's not cached
+// or if it differs from the cached one. It's safe to call get on nil cache,
+// the query is compiled every time.
+func (c *namedCache) get(key, query string) (*namedQuery, error) {
+	if c != nil {
+		c.mu.RLock()
+		q, ok := c.m[key]
+		c.mu.RUnlock()
+		if ok && q.query == query {
+			return q, nil
+		}
+	}
+
+	stmt, names, err := CompileNamedQuery([]byte(query))
+	if err != nil {
+		return nil, err
+	}
+	q := &namedQuery{
+		query: query,
+		stmt:  stmt,
+		names: names,
+	}
+
+	if c != nil {
+		c.mu.Lock()
+		c.m[key] = q
+		c.mu.Unlock()
+	}
+	return q, nil
+}
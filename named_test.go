@@ -0,0 +1,79 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNamedCache(t *testing.T) {
+	const query = "SELECT * FROM cycling.cyclist_name WHERE id=:id AND firstname=:name"
+
+	c := newNamedCache()
+	q0, err := c.get("get", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("SELECT * FROM cycling.cyclist_name WHERE id=? AND firstname=?", q0.stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"id", "name"}, q0.names); diff != "" {
+		t.Error(diff)
+	}
+
+	t.Run("reused", func(t *testing.T) {
+		q1, err := c.get("get", query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q0 != q1 {
+			t.Fatal("expected cached entry to be reused")
+		}
+	})
+
+	t.Run("query changed", func(t *testing.T) {
+		q1, err := c.get("get", "SELECT * FROM cycling.cyclist_name WHERE id=:id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q0 == q1 {
+			t.Fatal("expected query to be recompiled")
+		}
+		if diff := cmp.Diff([]string{"id"}, q1.names); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("nil cache", func(t *testing.T) {
+		var c *namedCache
+		q, err := c.get("get", query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"id", "name"}, q.names); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func BenchmarkNamedCache(b *testing.B) {
+	const query = "INSERT INTO cycling.cyclist_name (id, user_uuid, firstname, stars) VALUES (:id, :user_uuid, :firstname, :stars)"
+
+	b.Run("cached", func(b *testing.B) {
+		c := newNamedCache()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.get("insert", query)
+		}
+	})
+
+	b.Run("compile", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			CompileNamedQuery([]byte(query))
+		}
+	})
+}
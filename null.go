@@ -0,0 +1,116 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// NullPolicy specifies how StructScan handles NULL values scanned into fields
+// that cannot represent NULL. Pointers, slices, maps, interfaces and types
+// implementing gocql.Unmarshaler, like NullString, can represent NULL and are
+// not affected by the policy.
+type NullPolicy int
+
+const (
+	// NullZero scans NULL as the field zero value, this is the default.
+	NullZero NullPolicy = iota
+	// NullError reports an error when NULL is scanned into a field that
+	// cannot represent NULL.
+	NullError
+	// NullRequirePointer reports an error before scanning the first row if
+	// any of the fields mapped to result columns cannot represent NULL.
+	NullRequirePointer
+)
+
+// nullable returns true if value of type t can represent NULL.
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return reflect.PtrTo(t).Implements(unmarshallerInterface)
+}
+
+// NullString represents a string that may be null, it's similar to
+// sql.NullString and can be used as a struct field instead of *string.
+// If Valid is false the value is bound as null.
+type NullString struct {
+	String string
+	Valid  bool
+}
+
+// MarshalCQL implements gocql.Marshaler.
+func (n NullString) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return gocql.Marshal(info, n.String)
+}
+
+// UnmarshalCQL implements gocql.Unmarshaler.
+func (n *NullString) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if data == nil {
+		*n = NullString{}
+		return nil
+	}
+	n.Valid = true
+	return gocql.Unmarshal(info, data, &n.String)
+}
+
+// NullInt64 represents an int64 that may be null, it's similar to
+// sql.NullInt64 and can be used as a struct field instead of *int64.
+// If Valid is false the value is bound as null.
+type NullInt64 struct {
+	Int64 int64
+	Valid bool
+}
+
+// MarshalCQL implements gocql.Marshaler.
+func (n NullInt64) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return gocql.Marshal(info, n.Int64)
+}
+
+// UnmarshalCQL implements gocql.Unmarshaler.
+func (n *NullInt64) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if data == nil {
+		*n = NullInt64{}
+		return nil
+	}
+	n.Valid = true
+	return gocql.Unmarshal(info, data, &n.Int64)
+}
+
+// NullTime represents a time.Time that may be null, it's similar to
+// sql.NullTime and can be used as a struct field instead of *time.Time.
+// If Valid is false the value is bound as null.
+type NullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// MarshalCQL implements gocql.Marshaler.
+func (n NullTime) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return gocql.Marshal(info, n.Time)
+}
+
+// UnmarshalCQL implements gocql.Unmarshaler.
+func (n *NullTime) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if data == nil {
+		*n = NullTime{}
+		return nil
+	}
+	n.Valid = true
+	return gocql.Unmarshal(info, data, &n.Time)
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNullTypes(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond).UTC()
+
+	table := []struct {
+		Name string
+		Info gocql.TypeInfo
+		V    gocql.Marshaler
+		New  func() gocql.Unmarshaler
+	}{
+		{
+			Name: "string",
+			Info: gocql.NewNativeType(4, gocql.TypeText, ""),
+			V:    NullString{String: "foo", Valid: true},
+			New:  func() gocql.Unmarshaler { return new(NullString) },
+		},
+		{
+			Name: "string null",
+			Info: gocql.NewNativeType(4, gocql.TypeText, ""),
+			V:    NullString{},
+			New:  func() gocql.Unmarshaler { return new(NullString) },
+		},
+		{
+			Name: "string empty",
+			Info: gocql.NewNativeType(4, gocql.TypeText, ""),
+			V:    NullString{String: "", Valid: true},
+			New:  func() gocql.Unmarshaler { return new(NullString) },
+		},
+		{
+			Name: "int64",
+			Info: gocql.NewNativeType(4, gocql.TypeBigInt, ""),
+			V:    NullInt64{Int64: 42, Valid: true},
+			New:  func() gocql.Unmarshaler { return new(NullInt64) },
+		},
+		{
+			Name: "int64 null",
+			Info: gocql.NewNativeType(4, gocql.TypeBigInt, ""),
+			V:    NullInt64{},
+			New:  func() gocql.Unmarshaler { return new(NullInt64) },
+		},
+		{
+			Name: "time",
+			Info: gocql.NewNativeType(4, gocql.TypeTimestamp, ""),
+			V:    NullTime{Time: now, Valid: true},
+			New:  func() gocql.Unmarshaler { return new(NullTime) },
+		},
+		{
+			Name: "time null",
+			Info: gocql.NewNativeType(4, gocql.TypeTimestamp, ""),
+			V:    NullTime{},
+			New:  func() gocql.Unmarshaler { return new(NullTime) },
+		},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			data, err := gocql.Marshal(test.Info, test.V)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := test.New()
+			if err := gocql.Unmarshal(test.Info, data, v); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.V, reflect.ValueOf(v).Elem().Interface()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestNullable(t *testing.T) {
+	table := []struct {
+		V interface{}
+		E bool
+	}{
+		{V: 0},
+		{V: ""},
+		{V: time.Time{}},
+		{V: struct{}{}},
+		{V: new(int), E: true},
+		{V: []string{}, E: true},
+		{V: map[string]int{}, E: true},
+		{V: NullString{}, E: true},
+	}
+
+	for _, test := range table {
+		if v := nullable(reflect.TypeOf(test.V)); v != test.E {
+			t.Errorf("%T expected %v got %v", test.V, test.E, v)
+		}
+	}
+}
+
+func TestColumnScannerNotNull(t *testing.T) {
+	info := gocql.NewNativeType(4, gocql.TypeInt, "")
+
+	var v int
+	s := &columnScanner{column: "age", field: "Person.Age", dest: &v}
+	if err := s.UnmarshalCQL(info, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	s.notNull = true
+	if err := s.UnmarshalCQL(info, nil); err == nil || err.Error() != `cannot scan NULL column "age" (int) into field Person.Age (int)` {
+		t.Fatal("expected null error got", err)
+	}
+	if err := s.UnmarshalCQL(info, []byte{0, 0, 0, 1}); err != nil || v != 1 {
+		t.Fatal("expected 1 got", v, err)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"gopkg.in/inf.v0"
+)
+
+// DecimalFromString parses a decimal number i.e. "-12.345" into *inf.Dec that
+// can be bound to a decimal column. It's useful for ingesting numeric data
+// represented as strings i.e. in JSON.
+func DecimalFromString(s string) (*inf.Dec, error) {
+	d, ok := new(inf.Dec).SetString(strings.TrimSpace(s))
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	return d, nil
+}
+
+// VarintFromString parses a base 10 integer i.e. "-12345" into *big.Int that
+// can be bound to a varint column.
+func VarintFromString(s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid varint %q", s)
+	}
+	return i, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+)
+
+func TestDecimalFromString(t *testing.T) {
+	table := []struct {
+		S   string
+		Dec string
+		Err string
+	}{
+		{S: "0", Dec: "0"},
+		{S: "-12.345", Dec: "-12.345"},
+		{S: " 1.50 ", Dec: "1.50"},
+		{S: "123456789012345678901234567890.1", Dec: "123456789012345678901234567890.1"},
+		{S: "", Err: `invalid decimal ""`},
+		{S: "1.2.3", Err: `invalid decimal "1.2.3"`},
+		{S: "abc", Err: `invalid decimal "abc"`},
+	}
+
+	for _, test := range table {
+		d, err := DecimalFromString(test.S)
+		if test.Err != "" {
+			if err == nil || err.Error() != test.Err {
+				t.Errorf("DecimalFromString(%q) expected error %q got %v", test.S, test.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != test.Dec {
+			t.Errorf("DecimalFromString(%q)=%s expected %s", test.S, d, test.Dec)
+		}
+	}
+}
+
+func TestVarintFromString(t *testing.T) {
+	table := []struct {
+		S   string
+		Int string
+		Err string
+	}{
+		{S: "0", Int: "0"},
+		{S: "-12345", Int: "-12345"},
+		{S: "123456789012345678901234567890", Int: "123456789012345678901234567890"},
+		{S: "", Err: `invalid varint ""`},
+		{S: "1.5", Err: `invalid varint "1.5"`},
+		{S: "0x10", Err: `invalid varint "0x10"`},
+	}
+
+	for _, test := range table {
+		i, err := VarintFromString(test.S)
+		if test.Err != "" {
+			if err == nil || err.Error() != test.Err {
+				t.Errorf("VarintFromString(%q) expected error %q got %v", test.S, test.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i.String() != test.Int {
+			t.Errorf("VarintFromString(%q)=%s expected %s", test.S, i, test.Int)
+		}
+	}
+}
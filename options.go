@@ -0,0 +1,51 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+
+	"github.com/gocql/gocql"
+)
+
+// QueryOptions groups query settings that are usually shared by many queries,
+// it allows to define the policy once and apply it with ApplyOptions instead
+// of chaining the setters on every query. Zero value fields are not applied,
+// the query keeps its current (or session default) setting.
+type QueryOptions struct {
+	// Consistency is the consistency level, note that gocql.Any is the zero
+	// value and can not be set this way.
+	Consistency gocql.Consistency
+	// SerialConsistency is the consistency level for the serial phase of
+	// conditional updates.
+	SerialConsistency gocql.SerialConsistency
+	// PageSize is the number of rows fetched in a single page, a negative
+	// value disables paging see Queryx.PageSize.
+	PageSize int
+	// Idempotent marks the query as idempotent.
+	Idempotent bool
+	// Context is the context the query is executed with.
+	Context context.Context
+}
+
+// ApplyOptions sets all the non zero options on the query.
+func (q *Queryx) ApplyOptions(opts QueryOptions) *Queryx {
+	if opts.Consistency != 0 {
+		q.Consistency(opts.Consistency)
+	}
+	if opts.SerialConsistency != 0 {
+		q.SerialConsistency(opts.SerialConsistency)
+	}
+	if opts.PageSize != 0 {
+		q.PageSize(opts.PageSize)
+	}
+	if opts.Idempotent {
+		q.Idempotent(true)
+	}
+	if opts.Context != nil {
+		q.WithContext(opts.Context)
+	}
+	return q
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestApplyOptions(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+
+	q := Query(&gocql.Query{}, nil).ApplyOptions(QueryOptions{
+		Consistency:       gocql.LocalQuorum,
+		SerialConsistency: gocql.LocalSerial,
+		PageSize:          10,
+		Idempotent:        true,
+		Context:           ctx,
+	})
+
+	if c := q.GetConsistency(); c != gocql.LocalQuorum {
+		t.Error("expected consistency", gocql.LocalQuorum, "got", c)
+	}
+	// gocql.Query does not expose getters for page size and serial consistency
+	v := reflect.ValueOf(q.Query).Elem()
+	if c := gocql.SerialConsistency(v.FieldByName("serialCons").Uint()); c != gocql.LocalSerial {
+		t.Error("expected serial consistency", gocql.LocalSerial, "got", c)
+	}
+	if n := v.FieldByName("pageSize").Int(); n != 10 {
+		t.Error("expected page size 10 got", n)
+	}
+	if !q.IsIdempotent() {
+		t.Error("expected idempotent")
+	}
+	if q.Context().Value(key{}) != "value" {
+		t.Error("expected context")
+	}
+
+	t.Run("zero value", func(t *testing.T) {
+		q := Query(&gocql.Query{}, nil).Consistency(gocql.One).PageSize(5).ApplyOptions(QueryOptions{})
+
+		if c := q.GetConsistency(); c != gocql.One {
+			t.Error("expected consistency", gocql.One, "got", c)
+		}
+		if n := reflect.ValueOf(q.Query).Elem().FieldByName("pageSize").Int(); n != 5 {
+			t.Error("expected page size 5 got", n)
+		}
+		if q.IsIdempotent() {
+			t.Error("expected not idempotent")
+		}
+	})
+}
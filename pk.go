@@ -0,0 +1,49 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/scylladb/go-reflectx"
+	"github.com/scylladb/gocqlx/qb"
+)
+
+// ByPrimaryKey returns equality comparators for the keys columns that can be
+// used in a WHERE clause i.e. qb.Select(table).Where(cmps...). The model must
+// be a struct or a struct pointer with a field mapped by DefaultMapper for
+// every key, so that the query can be bound with BindStruct(model). To use
+// a custom mapper call Session.ByPrimaryKey.
+func ByPrimaryKey(model interface{}, keys ...string) ([]qb.Cmp, error) {
+	return byPrimaryKey(defaultMapper(), model, keys)
+}
+
+// ByPrimaryKey is like the package level ByPrimaryKey but the key fields are
+// resolved with the session Mapper.
+func (s Session) ByPrimaryKey(model interface{}, keys ...string) ([]qb.Cmp, error) {
+	return byPrimaryKey(s.Mapper, model, keys)
+}
+
+func byPrimaryKey(mapper *reflectx.Mapper, model interface{}, keys []string) ([]qb.Cmp, error) {
+	t := reflectx.Deref(reflect.TypeOf(model))
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct but got %T", model)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no primary key columns")
+	}
+
+	m := mapper.TypeMap(t)
+	cmps := make([]qb.Cmp, len(keys))
+	for i, k := range keys {
+		if _, ok := m.Names[k]; !ok {
+			return nil, fmt.Errorf("missing primary key field %q in %T", k, model)
+		}
+		cmps[i] = qb.Eq(k)
+	}
+	return cmps, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/go-reflectx"
+	"github.com/scylladb/gocqlx/qb"
+)
+
+func TestByPrimaryKey(t *testing.T) {
+	type Model struct {
+		ID    int
+		Name  string `db:"n"`
+		Value string
+	}
+	m := &Model{ID: 1, Name: "name", Value: "value"}
+
+	cmps, err := ByPrimaryKey(m, "id", "n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stmt, names := qb.Select("table").Where(cmps...).ToCql()
+	if diff := cmp.Diff("SELECT * FROM table WHERE id=? AND n=? ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	args, err := bindStructArgs(names, m, nil, DefaultMapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]interface{}{1, "name"}, args); diff != "" {
+		t.Error(diff)
+	}
+
+	t.Run("missing field", func(t *testing.T) {
+		if _, err := ByPrimaryKey(m, "id", "name"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		if _, err := ByPrimaryKey(m); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("session mapper", func(t *testing.T) {
+		s := Session{Mapper: reflectx.NewMapperFunc("db", strings.ToUpper)}
+		cmps, err := s.ByPrimaryKey(m, "ID", "n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		stmt, _ := qb.Select("table").Where(cmps...).ToCql()
+		if diff := cmp.Diff("SELECT * FROM table WHERE ID=? AND n=? ", stmt); diff != "" {
+			t.Error(diff)
+		}
+		if _, err := s.ByPrimaryKey(m, "id"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		if _, err := ByPrimaryKey(1, "id"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
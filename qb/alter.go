@@ -0,0 +1,84 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// ALTER TABLE reference:
+// https://cassandra.apache.org/doc/latest/cql/ddl.html#alter-table
+
+import (
+	"bytes"
+)
+
+// AlterTableBuilder builds CQL ALTER TABLE statements that add or drop
+// columns.
+type AlterTableBuilder struct {
+	table    string
+	keyspace string
+	add      columns
+	drop     columns
+}
+
+// AlterTable returns a new AlterTableBuilder with the given table name.
+func AlterTable(table string) *AlterTableBuilder {
+	return &AlterTableBuilder{
+		table: table,
+	}
+}
+
+// ToCql builds the query into a CQL string, names are always empty.
+func (b *AlterTableBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("ALTER TABLE ")
+	cql.WriteString(withKeyspace(b.table, b.keyspace))
+	cql.WriteByte(' ')
+
+	switch {
+	case len(b.add) > 0:
+		cql.WriteString("ADD ")
+		writeAlterColumns(&cql, b.add)
+	case len(b.drop) > 0:
+		cql.WriteString("DROP ")
+		writeAlterColumns(&cql, b.drop)
+	}
+
+	stmt = cql.String()
+	return
+}
+
+func writeAlterColumns(cql *bytes.Buffer, c columns) {
+	if len(c) > 1 {
+		cql.WriteByte('(')
+		c.writeCql(cql)
+		cql.WriteByte(')')
+	} else {
+		c.writeCql(cql)
+	}
+	cql.WriteByte(' ')
+}
+
+// WithKeyspace sets the keyspace of the table, it replaces the keyspace the
+// table name is qualified with, if any, when the query is built.
+func (b *AlterTableBuilder) WithKeyspace(keyspace string) *AlterTableBuilder {
+	b.keyspace = keyspace
+	return b
+}
+
+// AddColumn adds a column of the given CQL type i.e. "int" or "set<text>" to
+// the table. A statement can either add or drop columns, calling AddColumn
+// discards columns set with DropColumn.
+func (b *AlterTableBuilder) AddColumn(column, cqlType string) *AlterTableBuilder {
+	b.drop = nil
+	b.add = append(b.add, column+" "+cqlType)
+	return b
+}
+
+// DropColumn drops the columns from the table. A statement can either add or
+// drop columns, calling DropColumn discards columns set with AddColumn.
+func (b *AlterTableBuilder) DropColumn(columns ...string) *AlterTableBuilder {
+	b.add = nil
+	b.drop = append(b.drop, columns...)
+	return b
+}
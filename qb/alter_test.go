@@ -0,0 +1,63 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAlterTableBuilder(t *testing.T) {
+	table := []struct {
+		B *AlterTableBuilder
+		S string
+	}{
+		// Basic test for add column
+		{
+			B: AlterTable("cycling.cyclist_name").AddColumn("age", "int"),
+			S: "ALTER TABLE cycling.cyclist_name ADD age int ",
+		},
+		// Add multiple columns
+		{
+			B: AlterTable("cycling.cyclist_name").AddColumn("age", "int").AddColumn("teams", "set<text>"),
+			S: "ALTER TABLE cycling.cyclist_name ADD (age int,teams set<text>) ",
+		},
+		// Basic test for drop column
+		{
+			B: AlterTable("cycling.cyclist_name").DropColumn("age"),
+			S: "ALTER TABLE cycling.cyclist_name DROP age ",
+		},
+		// Drop multiple columns
+		{
+			B: AlterTable("cycling.cyclist_name").DropColumn("age", "teams"),
+			S: "ALTER TABLE cycling.cyclist_name DROP (age,teams) ",
+		},
+		// Last of add and drop wins
+		{
+			B: AlterTable("cycling.cyclist_name").AddColumn("age", "int").DropColumn("teams"),
+			S: "ALTER TABLE cycling.cyclist_name DROP teams ",
+		},
+		{
+			B: AlterTable("cycling.cyclist_name").DropColumn("teams").AddColumn("age", "int"),
+			S: "ALTER TABLE cycling.cyclist_name ADD age int ",
+		},
+		// Add WithKeyspace
+		{
+			B: AlterTable("cycling.cyclist_name").AddColumn("age", "int").WithKeyspace("tenant"),
+			S: "ALTER TABLE tenant.cyclist_name ADD age int ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if len(names) != 0 {
+			t.Error("expected no names got", names)
+		}
+	}
+}
@@ -55,6 +55,14 @@ func (b *BatchBuilder) ToCql() (stmt string, names []string) {
 	return
 }
 
+// Names returns the named args of the batch in the same order as ToCql, that
+// is the USING clause parameters followed by the names of the added
+// statements, without building the statement.
+func (b *BatchBuilder) Names() []string {
+	names := b.using.names()
+	return append(names, b.names...)
+}
+
 // Add builds the builder and adds the statement to the batch.
 func (b *BatchBuilder) Add(builder Builder) *BatchBuilder {
 	return b.AddStmt(builder.ToCql())
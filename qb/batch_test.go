@@ -75,6 +75,7 @@ func TestBatchBuilder(t *testing.T) {
 	}
 
 	for _, test := range table {
+		n := test.B.Names()
 		stmt, names := test.B.ToCql()
 		if diff := cmp.Diff(test.S, stmt); diff != "" {
 			t.Error(diff)
@@ -82,5 +83,8 @@ func TestBatchBuilder(t *testing.T) {
 		if diff := cmp.Diff(test.N, names); diff != "" {
 			t.Error(diff)
 		}
+		if diff := cmp.Diff(test.N, n); diff != "" {
+			t.Error("Names() mismatch", diff)
+		}
 	}
 }
@@ -6,6 +6,7 @@ package qb
 
 import (
 	"bytes"
+	"errors"
 )
 
 // op specifies Cmd operation type.
@@ -22,6 +23,7 @@ const (
 	cnt
 	cntKey
 	like
+	notNull
 )
 
 // Cmp if a filtering comparator that is used in WHERE and IF clauses.
@@ -56,10 +58,20 @@ func (c Cmp) writeCql(cql *bytes.Buffer) (names []string) {
 		cql.WriteString(" CONTAINS KEY ")
 	case like:
 		cql.WriteString(" LIKE ")
+	case notNull:
+		cql.WriteString(" IS NOT NULL")
+		return nil
 	}
 	return c.value.writeCql(cql)
 }
 
+func (c Cmp) names() []string {
+	if c.op == notNull {
+		return nil
+	}
+	return c.value.names()
+}
+
 // Eq produces column=?.
 func Eq(column string) Cmp {
 	return Cmp{
@@ -378,6 +390,17 @@ func InNamed(column, name string) Cmp {
 	}
 }
 
+// InNamedElems produces column IN (?,?,...) with a parameter for every name,
+// this allows to bind IN elements individually i.e. with BindMap. Unlike
+// InNamed the tuple form is produced for any number of names.
+func InNamedElems(column string, names ...string) Cmp {
+	return Cmp{
+		op:     in,
+		column: column,
+		value:  namedTupleParam(names),
+	}
+}
+
 // InLit produces column IN literal and does not add a parameter to the query.
 func InLit(column, literal string) Cmp {
 	return Cmp{
@@ -477,6 +500,24 @@ func LikeTuple(column string, count int) Cmp {
 	}
 }
 
+// LikeNamed produces column LIKE ? with a custom parameter name.
+func LikeNamed(column, name string) Cmp {
+	return Cmp{
+		op:     like,
+		column: column,
+		value:  param(name),
+	}
+}
+
+// IsNotNull produces column IS NOT NULL and does not add a parameter to the
+// query. It's mainly used in materialized view definitions.
+func IsNotNull(column string) Cmp {
+	return Cmp{
+		op:     notNull,
+		column: column,
+	}
+}
+
 type cmps []Cmp
 
 func (cs cmps) writeCql(cql *bytes.Buffer) (names []string) {
@@ -490,6 +531,13 @@ func (cs cmps) writeCql(cql *bytes.Buffer) (names []string) {
 	return
 }
 
+func (cs cmps) names() (names []string) {
+	for _, c := range cs {
+		names = append(names, c.names()...)
+	}
+	return
+}
+
 type where cmps
 
 func (w where) writeCql(cql *bytes.Buffer) (names []string) {
@@ -511,3 +559,11 @@ func (w _if) writeCql(cql *bytes.Buffer) (names []string) {
 	cql.WriteString("IF ")
 	return cmps(w).writeCql(cql)
 }
+
+// ifExistsErr returns an error if IF conditions are combined with IF EXISTS.
+func ifExistsErr(w _if, exists bool) error {
+	if len(w) > 0 && exists {
+		return errors.New("IF conditions cannot be combined with IF EXISTS")
+	}
+	return nil
+}
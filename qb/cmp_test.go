@@ -150,6 +150,16 @@ func TestCmp(t *testing.T) {
 			S: "in IN ?",
 			N: []string{"name"},
 		},
+		{
+			C: InNamedElems("in", "n1", "n2", "n3"),
+			S: "in IN (?,?,?)",
+			N: []string{"n1", "n2", "n3"},
+		},
+		{
+			C: InNamedElems("in", "n1"),
+			S: "in IN (?)",
+			N: []string{"n1"},
+		},
 		{
 			C: ContainsNamed("cnt", "name"),
 			S: "cnt CONTAINS ?",
@@ -160,6 +170,17 @@ func TestCmp(t *testing.T) {
 			S: "cntKey CONTAINS KEY ?",
 			N: []string{"name"},
 		},
+		{
+			C: LikeNamed("like", "name"),
+			S: "like LIKE ?",
+			N: []string{"name"},
+		},
+
+		// IS NOT NULL
+		{
+			C: IsNotNull("nn"),
+			S: "nn IS NOT NULL",
+		},
 
 		// Literals
 		{
@@ -14,12 +14,13 @@ import (
 
 // DeleteBuilder builds CQL DELETE statements.
 type DeleteBuilder struct {
-	table   string
-	columns columns
-	using   using
-	where   where
-	_if     _if
-	exists  bool
+	table    string
+	keyspace string
+	columns  columns
+	using    using
+	where    where
+	_if      _if
+	exists   bool
 }
 
 // Delete returns a new DeleteBuilder with the given table name.
@@ -39,7 +40,7 @@ func (b *DeleteBuilder) ToCql() (stmt string, names []string) {
 		cql.WriteByte(' ')
 	}
 	cql.WriteString("FROM ")
-	cql.WriteString(b.table)
+	cql.WriteString(withKeyspace(b.table, b.keyspace))
 	cql.WriteByte(' ')
 
 	names = append(names, b.using.writeCql(&cql)...)
@@ -54,12 +55,37 @@ func (b *DeleteBuilder) ToCql() (stmt string, names []string) {
 	return
 }
 
+// Err returns an error if the query options are in conflict i.e. IF
+// conditions are combined with IF EXISTS. In that case the statement contains
+// both clauses and is rejected by the database.
+func (b *DeleteBuilder) Err() error {
+	return ifExistsErr(b._if, b.exists)
+}
+
+// Names returns the named args of the query in the same order as ToCql, that
+// is the USING, WHERE and IF clause parameters, without building
+// the statement. It allows to check that a struct or a map has all the values
+// needed to bind the query i.e. at startup.
+func (b *DeleteBuilder) Names() []string {
+	names := b.using.names()
+	names = append(names, cmps(b.where).names()...)
+	return append(names, cmps(b._if).names()...)
+}
+
 // From sets the table to be deleted from.
 func (b *DeleteBuilder) From(table string) *DeleteBuilder {
 	b.table = table
 	return b
 }
 
+// WithKeyspace sets the keyspace of the table, it replaces the keyspace the
+// table name is qualified with, if any, when the query is built. This allows
+// to reuse the same builder for tables in different keyspaces.
+func (b *DeleteBuilder) WithKeyspace(keyspace string) *DeleteBuilder {
+	b.keyspace = keyspace
+	return b
+}
+
 // Columns adds delete columns to the query.
 func (b *DeleteBuilder) Columns(columns ...string) *DeleteBuilder {
 	b.columns = append(b.columns, columns...)
@@ -87,13 +113,15 @@ func (b *DeleteBuilder) Where(w ...Cmp) *DeleteBuilder {
 }
 
 // If adds an expression to the IF clause of the query. Expressions are ANDed
-// together in the generated CQL.
+// together in the generated CQL. CQL does not allow to mix IF conditions with
+// IF EXISTS, see Err.
 func (b *DeleteBuilder) If(w ...Cmp) *DeleteBuilder {
 	b._if = append(b._if, w...)
 	return b
 }
 
-// Existing sets a IF EXISTS clause on the query.
+// Existing sets a IF EXISTS clause on the query. CQL does not allow to mix
+// IF EXISTS with IF conditions, see Err.
 func (b *DeleteBuilder) Existing() *DeleteBuilder {
 	b.exists = true
 	return b
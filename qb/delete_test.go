@@ -31,6 +31,12 @@ func TestDeleteBuilder(t *testing.T) {
 			S: "DELETE FROM Foobar WHERE id=? ",
 			N: []string{"expr"},
 		},
+		// Change keyspace
+		{
+			B: Delete("cyclist_name").Where(w).WithKeyspace("tenant"),
+			S: "DELETE FROM tenant.cyclist_name WHERE id=? ",
+			N: []string{"expr"},
+		},
 		// Add column
 		{
 			B: Delete("cycling.cyclist_name").Where(w).Columns("stars"),
@@ -67,6 +73,22 @@ func TestDeleteBuilder(t *testing.T) {
 			S: "DELETE FROM cycling.cyclist_name WHERE id=? IF firstname>? ",
 			N: []string{"expr", "firstname"},
 		},
+		{
+			B: Delete("cycling.cyclist_name").Where(w, Eq("lastname")).If(Eq("status"), Lt("stars")).TimestampNamed("ts"),
+			S: "DELETE FROM cycling.cyclist_name USING TIMESTAMP ? WHERE id=? AND lastname=? IF status=? AND stars<? ",
+			N: []string{"ts", "expr", "lastname", "status", "stars"},
+		},
+		{
+			B: Delete("cycling.cyclist_name").Where(w).If(ContainsNamed("tags", "tag"), Eq("status")),
+			S: "DELETE FROM cycling.cyclist_name WHERE id=? IF tags CONTAINS ? AND status=? ",
+			N: []string{"expr", "tag", "status"},
+		},
+		// IF and IF EXISTS can not be mixed, both are kept, see Err
+		{
+			B: Delete("cycling.cyclist_name").Where(w).If(Eq("status")).Existing(),
+			S: "DELETE FROM cycling.cyclist_name WHERE id=? IF status=? IF EXISTS ",
+			N: []string{"expr", "status"},
+		},
 		// Add TIMESTAMP
 		{
 			B: Delete("cycling.cyclist_name").Where(w).Timestamp(time.Date(2005, 05, 05, 0, 0, 0, 0, time.UTC)),
@@ -87,6 +109,7 @@ func TestDeleteBuilder(t *testing.T) {
 	}
 
 	for _, test := range table {
+		n := test.B.Names()
 		stmt, names := test.B.ToCql()
 		if diff := cmp.Diff(test.S, stmt); diff != "" {
 			t.Error(diff)
@@ -94,5 +117,28 @@ func TestDeleteBuilder(t *testing.T) {
 		if diff := cmp.Diff(test.N, names); diff != "" {
 			t.Error(diff, names)
 		}
+		if diff := cmp.Diff(test.N, n); diff != "" {
+			t.Error("Names() mismatch", diff)
+		}
+	}
+}
+
+func TestDeleteBuilderErr(t *testing.T) {
+	w := EqNamed("id", "expr")
+
+	if err := Delete("cycling.cyclist_name").Where(w).If(Eq("status")).Err(); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if err := Delete("cycling.cyclist_name").Where(w).Existing().Err(); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	for _, b := range []*DeleteBuilder{
+		Delete("cycling.cyclist_name").Where(w).If(Eq("status")).Existing(),
+		Delete("cycling.cyclist_name").Where(w).Existing().If(Eq("status")),
+	} {
+		if err := b.Err(); err == nil || err.Error() != "IF conditions cannot be combined with IF EXISTS" {
+			t.Fatal("expected error got", err)
+		}
 	}
 }
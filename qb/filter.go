@@ -0,0 +1,111 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Filter is a predicate of a filter spec, it specifies the comparison
+// operator and the value to compare a column with. Op is one of =, !=, <, <=,
+// >, >=, IN, CONTAINS, CONTAINS KEY and LIKE, the keyword operators are case
+// insensitive.
+type Filter struct {
+	Op    string
+	Value interface{}
+}
+
+// filterOps maps filter operators to comparator constructors.
+var filterOps = map[string]func(column string) Cmp{
+	"=":            Eq,
+	"!=":           Ne,
+	"<":            Lt,
+	"<=":           LtOrEq,
+	">":            Gt,
+	">=":           GtOrEq,
+	"IN":           In,
+	"CONTAINS":     Contains,
+	"CONTAINS KEY": ContainsKey,
+	"LIKE":         Like,
+}
+
+// WhereMap returns comparators and bind values for a filter spec mapping
+// column names to filters, it's intended for building dynamic queries i.e.
+// from user input. The comparators are sorted by column name so that the
+// statement is stable and can be prepared once, the values are keyed by the
+// bind names and can be bound with BindMap.
+//
+//    cmps, values, err := qb.WhereMap(map[string][]qb.Filter{
+//        "age":  {{Op: ">=", Value: 18}, {Op: "<", Value: 65}},
+//        "tags": {{Op: "CONTAINS", Value: "go"}},
+//    })
+//    stmt, names := qb.Select("cycling.cyclist_name").Where(cmps...).AllowFiltering().ToCql()
+//    q := gocqlx.Query(session.Query(stmt), names).BindMap(values)
+//
+// Column names must be valid unquoted CQL identifiers, they are written to the
+// statement as is. The bind name of a column with a single filter is the
+// column name, if a column has more filters i.e. a range with both bounds,
+// the names are suffixed with the filter index like in TupleColumn i.e. age_0
+// and age_1. An error is returned if a column name is not a valid identifier,
+// if an operator is not supported or if the bind names collide.
+func WhereMap(spec map[string][]Filter) ([]Cmp, M, error) {
+	columns := make([]string, 0, len(spec))
+	for column := range spec {
+		if !isIdentifier(column) {
+			return nil, nil, fmt.Errorf("invalid column name %q", column)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	var (
+		cmps   []Cmp
+		values = make(M)
+	)
+	for _, column := range columns {
+		filters := spec[column]
+		for i, f := range filters {
+			fn, ok := filterOps[strings.Join(strings.Fields(strings.ToUpper(f.Op)), " ")]
+			if !ok {
+				return nil, nil, fmt.Errorf("unsupported operator %q for column %q", f.Op, column)
+			}
+
+			name := column
+			if len(filters) > 1 {
+				name = column + "_" + strconv.Itoa(i)
+			}
+			if _, ok := values[name]; ok {
+				return nil, nil, fmt.Errorf("duplicate bind name %q for column %q", name, column)
+			}
+
+			c := fn(column)
+			c.value = param(name)
+			cmps = append(cmps, c)
+			values[name] = f.Value
+		}
+	}
+
+	return cmps, values, nil
+}
+
+// isIdentifier returns true if s is a valid unquoted CQL identifier.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		switch {
+		case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z':
+		case i > 0 && (b >= '0' && b <= '9' || b == '_'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWhereMap(t *testing.T) {
+	spec := map[string][]Filter{
+		"id":    {{Op: "=", Value: 1}},
+		"age":   {{Op: ">=", Value: 18}, {Op: "<", Value: 65}},
+		"name":  {{Op: "like", Value: "foo%"}},
+		"tags":  {{Op: "Contains", Value: "go"}},
+		"props": {{Op: "contains  key", Value: "color"}},
+		"team":  {{Op: "IN", Value: []string{"a", "b"}}},
+	}
+
+	cmps, values, err := WhereMap(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stmt, names := Select("cycling.cyclist_name").Where(cmps...).AllowFiltering().ToCql()
+	if diff := cmp.Diff("SELECT * FROM cycling.cyclist_name WHERE age>=? AND age<? AND id=? AND name LIKE ? AND props CONTAINS KEY ? AND tags CONTAINS ? AND team IN ? ALLOW FILTERING ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"age_0", "age_1", "id", "name", "props", "tags", "team"}, names); diff != "" {
+		t.Error(diff)
+	}
+	expected := M{
+		"id":    1,
+		"age_0": 18,
+		"age_1": 65,
+		"name":  "foo%",
+		"tags":  "go",
+		"props": "color",
+		"team":  []string{"a", "b"},
+	}
+	if diff := cmp.Diff(expected, values); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestWhereMapEmpty(t *testing.T) {
+	cmps, values, err := WhereMap(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cmps) != 0 || len(values) != 0 {
+		t.Fatal("expected no comparators and values got", cmps, values)
+	}
+
+	stmt, _ := Select("cycling.cyclist_name").Where(cmps...).ToCql()
+	if diff := cmp.Diff("SELECT * FROM cycling.cyclist_name ", stmt); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestWhereMapError(t *testing.T) {
+	table := []struct {
+		Spec map[string][]Filter
+		Err  string
+	}{
+		{
+			Spec: map[string][]Filter{"id": {{Op: "~", Value: 1}}},
+			Err:  `unsupported operator "~" for column "id"`,
+		},
+		{
+			Spec: map[string][]Filter{"id=1 OR x": {{Op: "=", Value: 1}}},
+			Err:  `invalid column name "id=1 OR x"`,
+		},
+		{
+			Spec: map[string][]Filter{"": {{Op: "=", Value: 1}}},
+			Err:  `invalid column name ""`,
+		},
+		{
+			Spec: map[string][]Filter{"1id": {{Op: "=", Value: 1}}},
+			Err:  `invalid column name "1id"`,
+		},
+		{
+			Spec: map[string][]Filter{"\"id\"": {{Op: "=", Value: 1}}},
+			Err:  `invalid column name "\"id\""`,
+		},
+		{
+			Spec: map[string][]Filter{
+				"age":   {{Op: ">", Value: 1}, {Op: "<", Value: 2}},
+				"age_1": {{Op: "=", Value: 1}},
+			},
+			Err: `duplicate bind name "age_1" for column "age_1"`,
+		},
+	}
+
+	for _, test := range table {
+		_, _, err := WhereMap(test.Spec)
+		if err == nil || err.Error() != test.Err {
+			t.Errorf("expected error %q got %v", test.Err, err)
+		}
+	}
+}
+
+func TestIsIdentifier(t *testing.T) {
+	for _, s := range []string{"id", "user_id", "ID2", "a_1_b"} {
+		if !isIdentifier(s) {
+			t.Errorf("expected %q to be an identifier", s)
+		}
+	}
+	for _, s := range []string{"", "_id", "1id", "id ", "id;", "a.b", "token(id)"} {
+		if isIdentifier(s) {
+			t.Errorf("expected %q not to be an identifier", s)
+		}
+	}
+}
@@ -27,6 +27,10 @@ func (f *Func) writeCql(cql *bytes.Buffer) (names []string) {
 	return
 }
 
+func (f *Func) names() []string {
+	return append([]string(nil), f.ParamNames...)
+}
+
 // Fn creates Func.
 func Fn(name string, paramNames ...string) *Func {
 	return &Func{
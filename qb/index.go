@@ -0,0 +1,73 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// CREATE INDEX reference:
+// https://cassandra.apache.org/doc/latest/cql/indexes.html#create-index
+
+import (
+	"bytes"
+)
+
+// CreateIndexBuilder builds CQL CREATE INDEX statements.
+type CreateIndexBuilder struct {
+	table       string
+	keyspace    string
+	column      string
+	name        string
+	ifNotExists bool
+}
+
+// CreateIndex returns a new CreateIndexBuilder with the given table name and
+// indexed column.
+func CreateIndex(table, column string) *CreateIndexBuilder {
+	return &CreateIndexBuilder{
+		table:  table,
+		column: column,
+	}
+}
+
+// ToCql builds the query into a CQL string, names are always empty.
+func (b *CreateIndexBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("CREATE INDEX ")
+	if b.ifNotExists {
+		cql.WriteString("IF NOT EXISTS ")
+	}
+	if b.name != "" {
+		cql.WriteString(b.name)
+		cql.WriteByte(' ')
+	}
+	cql.WriteString("ON ")
+	cql.WriteString(withKeyspace(b.table, b.keyspace))
+	cql.WriteString(" (")
+	cql.WriteString(b.column)
+	cql.WriteString(") ")
+
+	stmt = cql.String()
+	return
+}
+
+// WithKeyspace sets the keyspace of the table, it replaces the keyspace the
+// table is qualified with, if any, when the query is built.
+func (b *CreateIndexBuilder) WithKeyspace(keyspace string) *CreateIndexBuilder {
+	b.keyspace = keyspace
+	return b
+}
+
+// Name sets the index name, if not set the name is generated by the database.
+// Index names are not qualified with a keyspace, the index is created in the
+// keyspace of the table.
+func (b *CreateIndexBuilder) Name(name string) *CreateIndexBuilder {
+	b.name = name
+	return b
+}
+
+// IfNotExists sets a IF NOT EXISTS clause on the query.
+func (b *CreateIndexBuilder) IfNotExists() *CreateIndexBuilder {
+	b.ifNotExists = true
+	return b
+}
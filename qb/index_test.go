@@ -0,0 +1,58 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateIndexBuilder(t *testing.T) {
+	table := []struct {
+		B *CreateIndexBuilder
+		S string
+	}{
+		// Basic test for create index
+		{
+			B: CreateIndex("cycling.cyclist_name", "age"),
+			S: "CREATE INDEX ON cycling.cyclist_name (age) ",
+		},
+		// Add name
+		{
+			B: CreateIndex("cycling.cyclist_name", "age").Name("cyclist_age_idx"),
+			S: "CREATE INDEX cyclist_age_idx ON cycling.cyclist_name (age) ",
+		},
+		// Add IF NOT EXISTS
+		{
+			B: CreateIndex("cycling.cyclist_name", "age").Name("cyclist_age_idx").IfNotExists(),
+			S: "CREATE INDEX IF NOT EXISTS cyclist_age_idx ON cycling.cyclist_name (age) ",
+		},
+		{
+			B: CreateIndex("cycling.cyclist_name", "age").IfNotExists(),
+			S: "CREATE INDEX IF NOT EXISTS ON cycling.cyclist_name (age) ",
+		},
+		// Collection index
+		{
+			B: CreateIndex("cycling.cyclist_name", "KEYS(teams)"),
+			S: "CREATE INDEX ON cycling.cyclist_name (KEYS(teams)) ",
+		},
+		// Add keyspace
+		{
+			B: CreateIndex("cycling.cyclist_name", "age").WithKeyspace("tenant"),
+			S: "CREATE INDEX ON tenant.cyclist_name (age) ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if names != nil {
+			t.Error("expected no names got", names)
+		}
+	}
+}
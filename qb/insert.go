@@ -20,11 +20,12 @@ type initializer struct {
 
 // InsertBuilder builds CQL INSERT statements.
 type InsertBuilder struct {
-	table   string
-	columns []initializer
-	unique  bool
-	using   using
-	json    bool
+	table    string
+	keyspace string
+	columns  []initializer
+	unique   bool
+	using    using
+	json     bool
 }
 
 // Insert returns a new InsertBuilder with the given table name.
@@ -41,7 +42,7 @@ func (b *InsertBuilder) ToCql() (stmt string, names []string) {
 	cql.WriteString("INSERT ")
 
 	cql.WriteString("INTO ")
-	cql.WriteString(b.table)
+	cql.WriteString(withKeyspace(b.table, b.keyspace))
 	cql.WriteByte(' ')
 
 	if b.json {
@@ -78,12 +79,41 @@ func (b *InsertBuilder) ToCql() (stmt string, names []string) {
 	return
 }
 
+// Err returns an error if the query options are invalid i.e. Timeout is
+// negative, or in conflict i.e. raw USING options set with Using are combined
+// with TTL, Timestamp or Timeout.
+func (b *InsertBuilder) Err() error {
+	return b.using.err()
+}
+
+// Names returns the named args of the query in the same order as ToCql, that
+// is the column values followed by the USING clause parameters, without
+// building the statement. JSON inserts have no names.
+func (b *InsertBuilder) Names() []string {
+	if b.json {
+		return nil
+	}
+	var names []string
+	for _, c := range b.columns {
+		names = append(names, c.value.names()...)
+	}
+	return append(names, b.using.names()...)
+}
+
 // Into sets the INTO clause of the query.
 func (b *InsertBuilder) Into(table string) *InsertBuilder {
 	b.table = table
 	return b
 }
 
+// WithKeyspace sets the keyspace of the table, it replaces the keyspace the
+// table name is qualified with, if any, when the query is built. This allows
+// to reuse the same builder for tables in different keyspaces.
+func (b *InsertBuilder) WithKeyspace(keyspace string) *InsertBuilder {
+	b.keyspace = keyspace
+	return b
+}
+
 // Json sets the Json clause of the query.
 func (b *InsertBuilder) Json() *InsertBuilder {
 	b.json = true
@@ -170,3 +200,25 @@ func (b *InsertBuilder) TimestampNamed(name string) *InsertBuilder {
 	b.using.TimestampNamed(name)
 	return b
 }
+
+// Timeout adds USING TIMEOUT clause to the query, it sets a per-statement
+// server side timeout. Negative d is reported by Err.
+//
+// USING TIMEOUT is a feature specific to ScyllaDB.
+// See https://docs.scylladb.com/getting-started/dml/#using-timeout
+func (b *InsertBuilder) Timeout(d time.Duration) *InsertBuilder {
+	b.using.Timeout(d)
+	return b
+}
+
+// Using adds a raw USING clause to the query, options are written after the
+// USING keyword as is i.e. Using("TTL 86400 AND TIMESTAMP 1500000000000").
+// It's the caller's responsibility to format the options correctly.
+//
+// Using cannot be combined with TTL, Timestamp and Timeout, if both are set
+// Err returns an error and the statement contains two USING clauses, which is
+// rejected by the database.
+func (b *InsertBuilder) Using(options string) *InsertBuilder {
+	b.using.Raw(options)
+	return b
+}
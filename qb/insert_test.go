@@ -36,6 +36,12 @@ func TestInsertBuilder(t *testing.T) {
 			S: "INSERT INTO Foobar (id,user_uuid,firstname) VALUES (?,?,?) ",
 			N: []string{"id", "user_uuid", "firstname"},
 		},
+		// Change keyspace
+		{
+			B: Insert(`"cycling.ks".cyclist_name`).Columns("id").WithKeyspace("tenant"),
+			S: "INSERT INTO tenant.cyclist_name (id) VALUES (?) ",
+			N: []string{"id"},
+		},
 		// Add columns
 		{
 			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").Columns("stars"),
@@ -76,6 +82,49 @@ func TestInsertBuilder(t *testing.T) {
 			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TIMESTAMP ? ",
 			N: []string{"id", "user_uuid", "firstname", "ts"},
 		},
+		// Add TTL and TIMESTAMP
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").TTLNamed("ttl").TimestampNamed("ts"),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TTL ? AND TIMESTAMP ? ",
+			N: []string{"id", "user_uuid", "firstname", "ttl", "ts"},
+		},
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").TimestampNamed("ts").TTLNamed("ttl"),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TTL ? AND TIMESTAMP ? ",
+			N: []string{"id", "user_uuid", "firstname", "ttl", "ts"},
+		},
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").TimestampNamed("ts").TTL(time.Second),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TTL 1 AND TIMESTAMP ? ",
+			N: []string{"id", "user_uuid", "firstname", "ts"},
+		},
+		// Add USING
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").Using("TTL 1 AND TIMESTAMP 2"),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TTL 1 AND TIMESTAMP 2 ",
+			N: []string{"id", "user_uuid", "firstname"},
+		},
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").TTLNamed("ttl").Using("TTL 1"),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TTL 1 USING TTL ? ",
+			N: []string{"id", "user_uuid", "firstname", "ttl"},
+		},
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").Using("TTL 1").TimestampNamed("ts"),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TTL 1 USING TIMESTAMP ? ",
+			N: []string{"id", "user_uuid", "firstname", "ts"},
+		},
+		// Add TIMEOUT
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").Timeout(time.Second),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TIMEOUT 1s ",
+			N: []string{"id", "user_uuid", "firstname"},
+		},
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").TTLNamed("ttl").TimestampNamed("ts").Timeout(1500 * time.Millisecond),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TTL ? AND TIMESTAMP ? AND TIMEOUT 1500ms ",
+			N: []string{"id", "user_uuid", "firstname", "ttl", "ts"},
+		},
 		// Add TupleColumn
 		{
 			B: Insert("cycling.cyclist_name").TupleColumn("id", 2),
@@ -107,6 +156,7 @@ func TestInsertBuilder(t *testing.T) {
 	}
 
 	for _, test := range table {
+		n := test.B.Names()
 		stmt, names := test.B.ToCql()
 		if diff := cmp.Diff(test.S, stmt); diff != "" {
 			t.Error(diff)
@@ -114,5 +164,21 @@ func TestInsertBuilder(t *testing.T) {
 		if diff := cmp.Diff(test.N, names); diff != "" {
 			t.Error(diff)
 		}
+		if diff := cmp.Diff(test.N, n); diff != "" {
+			t.Error("Names() mismatch", diff)
+		}
+	}
+}
+
+func TestInsertBuilderErr(t *testing.T) {
+	if err := Insert("cycling.cyclist_name").Columns("id").Using("TTL 1").Err(); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if err := Insert("cycling.cyclist_name").Columns("id").TTL(time.Second).Err(); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	err := Insert("cycling.cyclist_name").Columns("id").TTLNamed("ttl").Using("TIMESTAMP 1").Err()
+	if err == nil || err.Error() != "raw USING options cannot be combined with TTL, TIMESTAMP or TIMEOUT" {
+		t.Fatal("expected error got", err)
 	}
 }
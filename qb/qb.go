@@ -12,3 +12,10 @@ type Builder interface {
 
 // M is a map.
 type M map[string]interface{}
+
+// Unset is a sentinel value that can be used in M passed to BindMap or
+// BindStructMap to leave a named parameter unset, it is bound as
+// gocql.UnsetValue. Unlike null an unset value does not create a tombstone.
+var Unset unset
+
+type unset struct{}
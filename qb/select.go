@@ -10,6 +10,8 @@ package qb
 import (
 	"bytes"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Order specifies sorting order.
@@ -32,15 +34,19 @@ func (o Order) String() string {
 // SelectBuilder builds CQL SELECT statements.
 type SelectBuilder struct {
 	table             string
+	keyspace          string
 	columns           columns
+	aliases           map[string]string
 	distinct          columns
 	where             where
 	groupBy           columns
 	orderBy           columns
 	limit             uint
+	limitName         string
 	limitPerPartition uint
 	allowFiltering    bool
 	bypassCache       bool
+	using             using
 	json              bool
 }
 
@@ -69,15 +75,15 @@ func (b *SelectBuilder) ToCql() (stmt string, names []string) {
 		b.groupBy.writeCql(&cql)
 		if len(b.columns) != 0 {
 			cql.WriteByte(',')
-			b.columns.writeCql(&cql)
+			b.resultColumns().writeCql(&cql)
 		}
 	case len(b.columns) == 0:
 		cql.WriteByte('*')
 	default:
-		b.columns.writeCql(&cql)
+		b.resultColumns().writeCql(&cql)
 	}
 	cql.WriteString(" FROM ")
-	cql.WriteString(b.table)
+	cql.WriteString(withKeyspace(b.table, b.keyspace))
 	cql.WriteByte(' ')
 
 	names = b.where.writeCql(&cql)
@@ -94,18 +100,21 @@ func (b *SelectBuilder) ToCql() (stmt string, names []string) {
 		cql.WriteByte(' ')
 	}
 
-	if b.limit != 0 {
-		cql.WriteString("LIMIT ")
-		cql.WriteString(fmt.Sprint(b.limit))
-		cql.WriteByte(' ')
-	}
-
 	if b.limitPerPartition != 0 {
 		cql.WriteString("PER PARTITION LIMIT ")
 		cql.WriteString(fmt.Sprint(b.limitPerPartition))
 		cql.WriteByte(' ')
 	}
 
+	if b.limit != 0 {
+		cql.WriteString("LIMIT ")
+		cql.WriteString(fmt.Sprint(b.limit))
+		cql.WriteByte(' ')
+	} else if b.limitName != "" {
+		cql.WriteString("LIMIT ? ")
+		names = append(names, b.limitName)
+	}
+
 	if b.allowFiltering {
 		cql.WriteString("ALLOW FILTERING ")
 	}
@@ -114,16 +123,37 @@ func (b *SelectBuilder) ToCql() (stmt string, names []string) {
 		cql.WriteString("BYPASS CACHE ")
 	}
 
+	names = append(names, b.using.writeCql(&cql)...)
+
 	stmt = cql.String()
 	return
 }
 
+// Names returns the named args of the query in the same order as ToCql, that
+// is the WHERE clause parameters followed by the LIMIT parameter, if set with
+// LimitNamed, without building the statement.
+func (b *SelectBuilder) Names() []string {
+	names := cmps(b.where).names()
+	if b.limit == 0 && b.limitName != "" {
+		names = append(names, b.limitName)
+	}
+	return append(names, b.using.names()...)
+}
+
 // From sets the table to be selected from.
 func (b *SelectBuilder) From(table string) *SelectBuilder {
 	b.table = table
 	return b
 }
 
+// WithKeyspace sets the keyspace of the table, it replaces the keyspace the
+// table name is qualified with, if any, when the query is built. This allows
+// to reuse the same builder for tables in different keyspaces.
+func (b *SelectBuilder) WithKeyspace(keyspace string) *SelectBuilder {
+	b.keyspace = keyspace
+	return b
+}
+
 // Json sets the clause of the query.
 func (b *SelectBuilder) Json() *SelectBuilder {
 	b.json = true
@@ -145,6 +175,63 @@ func As(column, name string) string {
 	return column + " AS " + name
 }
 
+// As sets an alias for the expr result column, if expr is not a result column
+// yet it's added to the query. This is useful for scanning aggregates and
+// functions like count(*), ttl(column) or writetime(column) into struct
+// fields.
+func (b *SelectBuilder) As(expr, alias string) *SelectBuilder {
+	if b.aliases == nil {
+		b.aliases = make(map[string]string)
+	}
+	if _, ok := b.aliases[expr]; !ok && !b.hasColumn(expr) {
+		b.Columns(expr)
+	}
+	b.aliases[expr] = alias
+	return b
+}
+
+func (b *SelectBuilder) hasColumn(column string) bool {
+	for _, c := range b.columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// resultColumns returns columns with aliases applied.
+func (b *SelectBuilder) resultColumns() columns {
+	if len(b.aliases) == 0 {
+		return b.columns
+	}
+	cols := make(columns, len(b.columns))
+	for i, c := range b.columns {
+		if alias, ok := b.aliases[c]; ok {
+			c = As(c, alias)
+		}
+		cols[i] = c
+	}
+	return cols
+}
+
+// TokenColumn adds token(columns) result column to the query, columns should
+// be the partition key columns. Note that CQL does not allow to mix * with
+// other selectors, all the needed columns must be listed.
+func (b *SelectBuilder) TokenColumn(columns ...string) *SelectBuilder {
+	b.Columns(tokenExpr(columns))
+	return b
+}
+
+// TokenColumnAs is like TokenColumn but sets an alias for the result column,
+// so that it can be scanned into a struct field.
+func (b *SelectBuilder) TokenColumnAs(alias string, columns ...string) *SelectBuilder {
+	return b.As(tokenExpr(columns), alias)
+}
+
+func tokenExpr(columns []string) string {
+	return fmt.Sprint("token(", strings.Join(columns, ","), ")")
+}
+
 // Distinct sets DISTINCT clause on the query.
 func (b *SelectBuilder) Distinct(columns ...string) *SelectBuilder {
 	if len(b.where) == 0 {
@@ -186,6 +273,16 @@ func (b *SelectBuilder) OrderBy(column string, o Order) *SelectBuilder {
 // Limit sets a LIMIT clause on the query.
 func (b *SelectBuilder) Limit(limit uint) *SelectBuilder {
 	b.limit = limit
+	b.limitName = ""
+	return b
+}
+
+// LimitNamed sets a LIMIT clause on the query with a custom parameter name.
+// Unlike Limit the value is bound at execution time so the same prepared
+// statement can be reused for different limits.
+func (b *SelectBuilder) LimitNamed(name string) *SelectBuilder {
+	b.limit = 0
+	b.limitName = name
 	return b
 }
 
@@ -201,6 +298,14 @@ func (b *SelectBuilder) AllowFiltering() *SelectBuilder {
 	return b
 }
 
+// AllowFilteringIf sets a ALLOW FILTERING clause on the query if cond is true
+// and removes it otherwise, it allows to enable filtering at runtime without
+// breaking the builder chain.
+func (b *SelectBuilder) AllowFilteringIf(cond bool) *SelectBuilder {
+	b.allowFiltering = cond
+	return b
+}
+
 // BypassCache sets a BYPASS CACHE clause on the query.
 //
 // BYPASS CACHE is a feature specific to ScyllaDB.
@@ -210,6 +315,22 @@ func (b *SelectBuilder) BypassCache() *SelectBuilder {
 	return b
 }
 
+// Timeout adds USING TIMEOUT clause to the query, it sets a per-statement
+// server side timeout. Negative d is reported by Err.
+//
+// USING TIMEOUT is a feature specific to ScyllaDB.
+// See https://docs.scylladb.com/getting-started/dml/#using-timeout
+func (b *SelectBuilder) Timeout(d time.Duration) *SelectBuilder {
+	b.using.Timeout(d)
+	return b
+}
+
+// Err returns an error if the query options are invalid i.e. Timeout is
+// negative.
+func (b *SelectBuilder) Err() error {
+	return b.using.err()
+}
+
 // Count produces 'count(column)'.
 func (b *SelectBuilder) Count(column string) *SelectBuilder {
 	b.fn("count", column)
@@ -222,6 +343,18 @@ func (b *SelectBuilder) CountAll() *SelectBuilder {
 	return b
 }
 
+// CountDistinct produces 'count(DISTINCT column)', unlike Distinct it applies
+// DISTINCT to the counted column only. If alias is given the result column is
+// aliased so that it can be scanned into a struct field.
+func (b *SelectBuilder) CountDistinct(column string, alias ...string) *SelectBuilder {
+	expr := "count(DISTINCT " + column + ")"
+	if len(alias) > 0 {
+		return b.As(expr, alias[0])
+	}
+	b.Columns(expr)
+	return b
+}
+
 // Min produces 'min(column)' aggregation function.
 func (b *SelectBuilder) Min(column string) *SelectBuilder {
 	b.fn("min", column)
@@ -6,6 +6,7 @@ package qb
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -33,6 +34,37 @@ func TestSelectBuilder(t *testing.T) {
 			B: Select("cycling.cyclist_name").Columns("id", "user_uuid", As("firstname", "name")),
 			S: "SELECT id,user_uuid,firstname AS name FROM cycling.cyclist_name ",
 		},
+		// Add a SELECT AS alias
+		{
+			B: Select("cycling.cyclist_name").Columns("id", "firstname").As("firstname", "name"),
+			S: "SELECT id,firstname AS name FROM cycling.cyclist_name ",
+		},
+		// Add a SELECT AS alias for a new column
+		{
+			B: Select("cycling.cyclist_name").Columns("id").As("writetime(firstname)", "firstname_wt"),
+			S: "SELECT id,writetime(firstname) AS firstname_wt FROM cycling.cyclist_name ",
+		},
+		// Add WHERE IS NOT NULL
+		{
+			B: Select("cycling.cyclist_name").Where(IsNotNull("firstname"), Eq("id")).AllowFiltering(),
+			S: "SELECT * FROM cycling.cyclist_name WHERE firstname IS NOT NULL AND id=? ALLOW FILTERING ",
+			N: []string{"id"},
+		},
+		// Add a token column
+		{
+			B: Select("cycling.cyclist_name").TokenColumn("id").Columns("firstname"),
+			S: "SELECT token(id),firstname FROM cycling.cyclist_name ",
+		},
+		{
+			B: Select("cycling.cyclist_name").Columns("id", "firstname").TokenColumnAs("tok", "id", "firstname"),
+			S: "SELECT id,firstname,token(id,firstname) AS tok FROM cycling.cyclist_name ",
+		},
+		// Resume token range scan from a raw token value
+		{
+			B: Select("cycling.cyclist_name").Columns("firstname").TokenColumnAs("token", "id").Where(Token("id").GtValue()).Limit(10),
+			S: "SELECT firstname,token(id) AS token FROM cycling.cyclist_name WHERE token(id)>? LIMIT 10 ",
+			N: []string{"token"},
+		},
 		// Basic test for select columns as JSON
 		{
 			B: Select("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").Json(),
@@ -59,6 +91,15 @@ func TestSelectBuilder(t *testing.T) {
 			B: Select("cycling.cyclist_name").From("Foobar"),
 			S: "SELECT * FROM Foobar ",
 		},
+		// Change keyspace
+		{
+			B: Select("cycling.cyclist_name").WithKeyspace("tenant"),
+			S: "SELECT * FROM tenant.cyclist_name ",
+		},
+		{
+			B: Select(`cycling."cyclist.name"`).WithKeyspace("tenant"),
+			S: `SELECT * FROM tenant."cyclist.name" `,
+		},
 		// Add WHERE
 		{
 			B: Select("cycling.cyclist_name").Where(w, Gt("firstname")),
@@ -116,6 +157,18 @@ func TestSelectBuilder(t *testing.T) {
 			S: "SELECT * FROM cycling.cyclist_name WHERE id=? LIMIT 10 ",
 			N: []string{"expr"},
 		},
+		// Add LIMIT with a custom parameter name
+		{
+			B: Select("cycling.cyclist_name").Where(w).LimitNamed("limit"),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? LIMIT ? ",
+			N: []string{"expr", "limit"},
+		},
+		// Add LIMIT with a custom parameter name overridden by Limit
+		{
+			B: Select("cycling.cyclist_name").Where(w).LimitNamed("limit").Limit(10),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? LIMIT 10 ",
+			N: []string{"expr"},
+		},
 		// Add PER PARTITION LIMIT
 		{
 			B: Select("cycling.cyclist_name").Where(w).LimitPerPartition(10),
@@ -128,6 +181,22 @@ func TestSelectBuilder(t *testing.T) {
 			S: "SELECT * FROM cycling.cyclist_name WHERE id=? ALLOW FILTERING ",
 			N: []string{"expr"},
 		},
+		// Add ALLOW FILTERING conditionally
+		{
+			B: Select("cycling.cyclist_name").Where(w).AllowFilteringIf(true),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? ALLOW FILTERING ",
+			N: []string{"expr"},
+		},
+		{
+			B: Select("cycling.cyclist_name").Where(w).AllowFilteringIf(false),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"expr"},
+		},
+		{
+			B: Select("cycling.cyclist_name").Where(w).AllowFiltering().AllowFilteringIf(false),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"expr"},
+		},
 		// Add ALLOW FILTERING and BYPASS CACHE
 		{
 			B: Select("cycling.cyclist_name").Where(w).AllowFiltering().BypassCache(),
@@ -140,6 +209,17 @@ func TestSelectBuilder(t *testing.T) {
 			S: "SELECT * FROM cycling.cyclist_name WHERE id=? BYPASS CACHE ",
 			N: []string{"expr"},
 		},
+		// Add USING TIMEOUT
+		{
+			B: Select("cycling.cyclist_name").Where(w).Timeout(200 * time.Millisecond),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? USING TIMEOUT 200ms ",
+			N: []string{"expr"},
+		},
+		{
+			B: Select("cycling.cyclist_name").Where(w).AllowFiltering().BypassCache().Timeout(time.Minute),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? ALLOW FILTERING BYPASS CACHE USING TIMEOUT 1m ",
+			N: []string{"expr"},
+		},
 		// Add COUNT all
 		{
 			B: Select("cycling.cyclist_name").CountAll().Where(Gt("stars")),
@@ -151,6 +231,32 @@ func TestSelectBuilder(t *testing.T) {
 			B: Select("cycling.cyclist_name").Count("stars").GroupBy("id"),
 			S: "SELECT id,count(stars) FROM cycling.cyclist_name GROUP BY id ",
 		},
+		// Add COUNT all with alias
+		{
+			B: Select("cycling.cyclist_name").CountAll().As("count(*)", "total"),
+			S: "SELECT count(*) AS total FROM cycling.cyclist_name ",
+		},
+		// Add COUNT with GROUP BY and alias
+		{
+			B: Select("cycling.cyclist_name").Count("stars").As("count(stars)", "stars_count").Max("stars").As("max(stars)", "max_stars").GroupBy("id"),
+			S: "SELECT id,count(stars) AS stars_count,max(stars) AS max_stars FROM cycling.cyclist_name GROUP BY id ",
+		},
+		// Add COUNT DISTINCT
+		{
+			B: Select("cycling.cyclist_name").CountDistinct("stars"),
+			S: "SELECT count(DISTINCT stars) FROM cycling.cyclist_name ",
+		},
+		// Add COUNT DISTINCT with alias
+		{
+			B: Select("cycling.cyclist_name").CountDistinct("stars", "stars_count").Where(w),
+			S: "SELECT count(DISTINCT stars) AS stars_count FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"expr"},
+		},
+		// Add COUNT DISTINCT with GROUP BY
+		{
+			B: Select("cycling.cyclist_name").Columns("id").CountDistinct("stars", "stars_count").GroupBy("country"),
+			S: "SELECT country,id,count(DISTINCT stars) AS stars_count FROM cycling.cyclist_name GROUP BY country ",
+		},
 		// Add Min
 		{
 			B: Select("cycling.cyclist_name").Min("stars"),
@@ -174,6 +280,7 @@ func TestSelectBuilder(t *testing.T) {
 	}
 
 	for _, test := range table {
+		n := test.B.Names()
 		stmt, names := test.B.ToCql()
 		if diff := cmp.Diff(test.S, stmt); diff != "" {
 			t.Error(diff)
@@ -181,5 +288,62 @@ func TestSelectBuilder(t *testing.T) {
 		if diff := cmp.Diff(test.N, names); diff != "" {
 			t.Error(diff)
 		}
+		if diff := cmp.Diff(test.N, n); diff != "" {
+			t.Error("Names() mismatch", diff)
+		}
+	}
+}
+
+func TestSelectBuilderWithKeyspace(t *testing.T) {
+	b := Select("cyclist_name").Where(Eq("id"))
+
+	for _, ks := range []string{"tenant_a", "tenant_b"} {
+		stmt, names := b.WithKeyspace(ks).ToCql()
+		if diff := cmp.Diff("SELECT * FROM "+ks+".cyclist_name WHERE id=? ", stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff([]string{"id"}, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
+
+func TestSelectBuilderClauseOrder(t *testing.T) {
+	stmt, names := Select("cycling.cyclist_name").
+		BypassCache().
+		AllowFiltering().
+		LimitNamed("limit").
+		LimitPerPartition(2).
+		OrderBy("race_id", DESC).
+		GroupBy("id", "race_id").
+		Where(Eq("id"), Gt("race_id")).
+		Max("stars").
+		Timeout(time.Second).
+		ToCql()
+
+	const expected = "SELECT id,race_id,max(stars) FROM cycling.cyclist_name " +
+		"WHERE id=? AND race_id>? " +
+		"GROUP BY id,race_id " +
+		"ORDER BY race_id DESC " +
+		"PER PARTITION LIMIT 2 " +
+		"LIMIT ? " +
+		"ALLOW FILTERING " +
+		"BYPASS CACHE " +
+		"USING TIMEOUT 1s "
+	if diff := cmp.Diff(expected, stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"id", "race_id", "limit"}, names); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSelectBuilderErr(t *testing.T) {
+	if err := Select("cycling.cyclist_name").Timeout(time.Second).Err(); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	err := Select("cycling.cyclist_name").Timeout(-time.Second).Err()
+	if err == nil || err.Error() != "TIMEOUT must not be negative, got -1s" {
+		t.Fatal("expected error got", err)
 	}
 }
@@ -0,0 +1,31 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// Statement holds a built CQL statement together with its named parameters.
+// It can be passed around instead of the two values returned by ToCql.
+type Statement struct {
+	Stmt  string
+	Names []string
+}
+
+// NewStatement creates a Statement, it can be called with the result of ToCql
+// i.e. NewStatement(Select("table").ToCql()).
+func NewStatement(stmt string, names []string) Statement {
+	return Statement{
+		Stmt:  stmt,
+		Names: names,
+	}
+}
+
+// Build builds the builder into a Statement.
+func Build(b Builder) Statement {
+	return NewStatement(b.ToCql())
+}
+
+// ToCql returns the statement and named args, this makes Statement a Builder.
+func (s Statement) ToCql() (stmt string, names []string) {
+	return s.Stmt, s.Names
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatement(t *testing.T) {
+	b := Select("cycling.cyclist_name").Where(Eq("id"))
+	stmt, names := b.ToCql()
+
+	table := []struct {
+		S Statement
+	}{
+		{
+			S: NewStatement(b.ToCql()),
+		},
+		{
+			S: Build(b),
+		},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(stmt, test.S.Stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(names, test.S.Names); diff != "" {
+			t.Error(diff)
+		}
+
+		s, n := test.S.ToCql()
+		if diff := cmp.Diff(stmt, s); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(names, n); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
@@ -10,6 +10,12 @@ import (
 )
 
 // TokenBuilder helps implement pagination using token function.
+//
+// The *Value functions compare the token with a raw int64 token value, not
+// wrapped in token(?), this allows to resume a token range scan from the last
+// token read i.e. with TokenColumnAs:
+//
+//     Select("t").TokenColumnAs("token", "pk").Where(Token("pk").GtValue())
 type TokenBuilder []string
 
 // Token creates a new TokenBuilder.
@@ -117,6 +123,18 @@ func (t TokenBuilder) GtOrEqValueNamed(name string) Cmp {
 	return t.valueCmp(geq, name)
 }
 
+// TokenRange produces token(column)>=? AND token(column)<? comparators with
+// parameter names lo and hi. The bound values are token values (int64), this
+// is the canonical way of splitting a full table scan into token ranges that
+// can be processed in parallel.
+func TokenRange(columns ...string) []Cmp {
+	t := Token(columns...)
+	return []Cmp{
+		t.GtOrEqValueNamed("lo"),
+		t.LtValueNamed("hi"),
+	}
+}
+
 func (t TokenBuilder) cmp(op op, names []string) Cmp {
 	s := names
 	if s == nil {
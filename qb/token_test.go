@@ -134,3 +134,32 @@ func TestToken(t *testing.T) {
 		}
 	}
 }
+
+func TestTokenRange(t *testing.T) {
+	table := []struct {
+		C []Cmp
+		S string
+		N []string
+	}{
+		{
+			C: TokenRange("a"),
+			S: "SELECT * FROM table WHERE token(a)>=? AND token(a)<? ",
+			N: []string{"lo", "hi"},
+		},
+		{
+			C: TokenRange("a", "b"),
+			S: "SELECT * FROM table WHERE token(a,b)>=? AND token(a,b)<? ",
+			N: []string{"lo", "hi"},
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := Select("table").Where(test.C...).ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
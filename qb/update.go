@@ -9,6 +9,9 @@ package qb
 
 import (
 	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
 	"time"
 )
 
@@ -26,14 +29,20 @@ func (a assignment) writeCql(cql *bytes.Buffer) (names []string) {
 	return a.value.writeCql(cql)
 }
 
+func (a assignment) names() []string {
+	return a.value.names()
+}
+
 // UpdateBuilder builds CQL UPDATE statements.
 type UpdateBuilder struct {
 	table       string
+	keyspace    string
 	using       using
 	assignments []assignment
 	where       where
 	_if         _if
 	exists      bool
+	err         error
 }
 
 // Update returns a new UpdateBuilder with the given table name.
@@ -48,7 +57,7 @@ func (b *UpdateBuilder) ToCql() (stmt string, names []string) {
 	cql := bytes.Buffer{}
 
 	cql.WriteString("UPDATE ")
-	cql.WriteString(b.table)
+	cql.WriteString(withKeyspace(b.table, b.keyspace))
 	cql.WriteByte(' ')
 
 	names = append(names, b.using.writeCql(&cql)...)
@@ -73,12 +82,32 @@ func (b *UpdateBuilder) ToCql() (stmt string, names []string) {
 	return
 }
 
+// Names returns the named args of the query in the same order as ToCql, that
+// is the USING clause parameters, the SET assignment values and the WHERE and
+// IF clause parameters, without building the statement.
+func (b *UpdateBuilder) Names() []string {
+	names := b.using.names()
+	for _, a := range b.assignments {
+		names = append(names, a.names()...)
+	}
+	names = append(names, cmps(b.where).names()...)
+	return append(names, cmps(b._if).names()...)
+}
+
 // Table sets the table to be updated.
 func (b *UpdateBuilder) Table(table string) *UpdateBuilder {
 	b.table = table
 	return b
 }
 
+// WithKeyspace sets the keyspace of the table, it replaces the keyspace the
+// table name is qualified with, if any, when the query is built. This allows
+// to reuse the same builder for tables in different keyspaces.
+func (b *UpdateBuilder) WithKeyspace(keyspace string) *UpdateBuilder {
+	b.keyspace = keyspace
+	return b
+}
+
 // TTL adds USING TTL clause to the query.
 func (b *UpdateBuilder) TTL(d time.Duration) *UpdateBuilder {
 	b.using.TTL(d)
@@ -104,6 +133,16 @@ func (b *UpdateBuilder) TimestampNamed(name string) *UpdateBuilder {
 	return b
 }
 
+// Timeout adds USING TIMEOUT clause to the query, it sets a per-statement
+// server side timeout. Negative d is reported by Err.
+//
+// USING TIMEOUT is a feature specific to ScyllaDB.
+// See https://docs.scylladb.com/getting-started/dml/#using-timeout
+func (b *UpdateBuilder) Timeout(d time.Duration) *UpdateBuilder {
+	b.using.Timeout(d)
+	return b
+}
+
 // Set adds SET clauses to the query.
 // To set a tuple column use SetTuple instead.
 func (b *UpdateBuilder) Set(columns ...string) *UpdateBuilder {
@@ -149,6 +188,69 @@ func (b *UpdateBuilder) SetTuple(column string, count int) *UpdateBuilder {
 	return b
 }
 
+// SetMapEntries adds SET column[key]=? clauses to the query, one for every
+// key in entries, which must be a map i.e. M or map[int]string. Keys are
+// rendered as CQL literals, strings are quoted, and must be of a scalar type,
+// otherwise an error is reported by Err. Clauses are sorted by key literals
+// and each value is bound using column[key] as the parameter name, so that
+// names do not collide with other columns, use MapEntries to get the bind
+// values.
+func (b *UpdateBuilder) SetMapEntries(column string, entries interface{}) *UpdateBuilder {
+	lits, err := mapEntryLiterals(entries)
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("SetMapEntries %s: %s", column, err)
+		}
+		return b
+	}
+
+	keys := make([]string, 0, len(lits))
+	for k := range lits {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		name := column + "[" + k + "]"
+		b.assignments = append(b.assignments, assignment{
+			column: name,
+			value:  param(name),
+		})
+	}
+	return b
+}
+
+// MapEntries returns values of entries keyed by the parameter names used by
+// SetMapEntries, the result can be passed to BindMap. Keys that cannot be
+// rendered as literals are skipped.
+func MapEntries(column string, entries interface{}) M {
+	lits, _ := mapEntryLiterals(entries)
+	m := make(M, len(lits))
+	for k, v := range lits {
+		m[column+"["+k+"]"] = v
+	}
+	return m
+}
+
+// mapEntryLiterals returns values of a map keyed by literals of the map keys.
+func mapEntryLiterals(entries interface{}) (map[string]interface{}, error) {
+	v := reflect.ValueOf(entries)
+	if v.Kind() != reflect.Map {
+		return nil, fmt.Errorf("expected a map but got %T", entries)
+	}
+
+	lits := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k, ok := scalarLiteral(iter.Key().Interface())
+		if !ok {
+			return nil, fmt.Errorf("unsupported map key %#v", iter.Key().Interface())
+		}
+		lits[k] = iter.Value().Interface()
+	}
+	return lits, nil
+}
+
 // Add adds SET column=column+? clauses to the query.
 func (b *UpdateBuilder) Add(column string) *UpdateBuilder {
 	return b.addValue(column, param(column))
@@ -195,6 +297,18 @@ func (b *UpdateBuilder) RemoveLit(column, literal string) *UpdateBuilder {
 	return b.removeValue(column, lit(literal))
 }
 
+// RemoveLitSet adds SET column=column-{literal, ...} clause to the query,
+// values are rendered as a CQL set literal and strings are quoted.
+func (b *UpdateBuilder) RemoveLitSet(column string, values ...interface{}) *UpdateBuilder {
+	return b.removeValue(column, collectionLit('{', '}', values))
+}
+
+// RemoveLitList adds SET column=column-[literal, ...] clause to the query,
+// values are rendered as a CQL list literal and strings are quoted.
+func (b *UpdateBuilder) RemoveLitList(column string, values ...interface{}) *UpdateBuilder {
+	return b.removeValue(column, collectionLit('[', ']', values))
+}
+
 // RemoveFunc adds SET column=column-someFunc(?...) clauses to the query.
 func (b *UpdateBuilder) RemoveFunc(column string, fn *Func) *UpdateBuilder {
 	return b.removeValue(column, fn)
@@ -221,7 +335,8 @@ func (b *UpdateBuilder) Where(w ...Cmp) *UpdateBuilder {
 }
 
 // If adds an expression to the IF clause of the query. Expressions are ANDed
-// together in the generated CQL.
+// together in the generated CQL. CQL does not allow to mix IF conditions with
+// IF EXISTS, see Err.
 func (b *UpdateBuilder) If(w ...Cmp) *UpdateBuilder {
 	if len(b._if) == 0 {
 		b._if = w
@@ -231,8 +346,23 @@ func (b *UpdateBuilder) If(w ...Cmp) *UpdateBuilder {
 	return b
 }
 
-// Existing sets a IF EXISTS clause on the query.
+// Existing sets a IF EXISTS clause on the query. CQL does not allow to mix
+// IF EXISTS with IF conditions, see Err.
 func (b *UpdateBuilder) Existing() *UpdateBuilder {
 	b.exists = true
 	return b
 }
+
+// Err returns an error if the query options are in conflict i.e. IF
+// conditions are combined with IF EXISTS, in that case the statement contains
+// both clauses and is rejected by the database, if SetMapEntries was called
+// with invalid entries or if Timeout is negative.
+func (b *UpdateBuilder) Err() error {
+	if b.err != nil {
+		return b.err
+	}
+	if err := b.using.err(); err != nil {
+		return err
+	}
+	return ifExistsErr(b._if, b.exists)
+}
@@ -5,6 +5,7 @@
 package qb
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -31,6 +32,12 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE Foobar SET id=?,user_uuid=?,firstname=? WHERE id=? ",
 			N: []string{"id", "user_uuid", "firstname", "expr"},
 		},
+		// Change keyspace
+		{
+			B: Update("cycling.cyclist_name").Set("id").Where(w).WithKeyspace("tenant"),
+			S: "UPDATE tenant.cyclist_name SET id=? WHERE id=? ",
+			N: []string{"id", "expr"},
+		},
 		// Add SET
 		{
 			B: Update("cycling.cyclist_name").Set("id", "user_uuid", "firstname").Where(w).Set("stars"),
@@ -56,6 +63,18 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE cycling.cyclist_name SET user_uuid=someFunc(?,?),stars=? WHERE id=? ",
 			N: []string{"param_0", "param_1", "stars", "expr"},
 		},
+		// Add SET SetMapEntries
+		{
+			B: Update("cycling.cyclist_name").SetMapEntries("teams", M{"2015": "Rabobank", "2014": "Lotto"}).Where(w),
+			S: "UPDATE cycling.cyclist_name SET teams['2014']=?,teams['2015']=? WHERE id=? ",
+			N: []string{"teams['2014']", "teams['2015']", "expr"},
+		},
+		// Add SET SetMapEntries with non-text keys
+		{
+			B: Update("cycling.cyclist_name").SetMapEntries("years", map[int]string{2015: "Rabobank", 2014: "Lotto"}).Where(w),
+			S: "UPDATE cycling.cyclist_name SET years[2014]=?,years[2015]=? WHERE id=? ",
+			N: []string{"years[2014]", "years[2015]", "expr"},
+		},
 		// Add SET Add
 		{
 			B: Update("cycling.cyclist_name").Add("total").Where(w),
@@ -92,6 +111,18 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE cycling.cyclist_name SET total=total-1 WHERE id=? ",
 			N: []string{"expr"},
 		},
+		// Add SET RemoveLitSet
+		{
+			B: Update("cycling.cyclist_name").RemoveLitSet("tags", "x", "it's").Where(w),
+			S: "UPDATE cycling.cyclist_name SET tags=tags-{'x','it''s'} WHERE id=? ",
+			N: []string{"expr"},
+		},
+		// Add SET RemoveLitList
+		{
+			B: Update("cycling.cyclist_name").RemoveLitList("scores", 1, 2).Where(w),
+			S: "UPDATE cycling.cyclist_name SET scores=scores-[1,2] WHERE id=? ",
+			N: []string{"expr"},
+		},
 		// Add WHERE
 		{
 			B: Update("cycling.cyclist_name").Set("id", "user_uuid", "firstname").Where(w, Gt("firstname")),
@@ -104,6 +135,11 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE cycling.cyclist_name SET id=?,user_uuid=?,firstname=? WHERE id=? IF firstname>? ",
 			N: []string{"id", "user_uuid", "firstname", "expr", "firstname"},
 		},
+		{
+			B: Update("cycling.cyclist_name").Set("firstname").Where(w).If(Contains("tags"), ContainsKeyNamed("props", "key")),
+			S: "UPDATE cycling.cyclist_name SET firstname=? WHERE id=? IF tags CONTAINS ? AND props CONTAINS KEY ? ",
+			N: []string{"firstname", "expr", "tags", "key"},
+		},
 		// Add TTL
 		{
 			B: Update("cycling.cyclist_name").Set("id", "user_uuid", "firstname").Where(w).TTL(time.Second),
@@ -126,6 +162,17 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE cycling.cyclist_name USING TIMESTAMP ? SET id=?,user_uuid=?,firstname=? WHERE id=? ",
 			N: []string{"ts", "id", "user_uuid", "firstname", "expr"},
 		},
+		// Add TIMEOUT
+		{
+			B: Update("cycling.cyclist_name").Set("id", "user_uuid", "firstname").Where(w).Timeout(time.Second),
+			S: "UPDATE cycling.cyclist_name USING TIMEOUT 1s SET id=?,user_uuid=?,firstname=? WHERE id=? ",
+			N: []string{"id", "user_uuid", "firstname", "expr"},
+		},
+		{
+			B: Update("cycling.cyclist_name").Set("id", "user_uuid", "firstname").Where(w).TTL(time.Second).Timeout(10 * time.Microsecond),
+			S: "UPDATE cycling.cyclist_name USING TTL 1 AND TIMEOUT 10us SET id=?,user_uuid=?,firstname=? WHERE id=? ",
+			N: []string{"id", "user_uuid", "firstname", "expr"},
+		},
 		// Add IF EXISTS
 		{
 			B: Update("cycling.cyclist_name").Set("id", "user_uuid", "firstname").Where(w).Existing(),
@@ -153,6 +200,7 @@ func TestUpdateBuilder(t *testing.T) {
 	}
 
 	for _, test := range table {
+		n := test.B.Names()
 		stmt, names := test.B.ToCql()
 		if diff := cmp.Diff(test.S, stmt); diff != "" {
 			t.Error(diff)
@@ -160,5 +208,93 @@ func TestUpdateBuilder(t *testing.T) {
 		if diff := cmp.Diff(test.N, names); diff != "" {
 			t.Error(diff)
 		}
+		if diff := cmp.Diff(test.N, n); diff != "" {
+			t.Error("Names() mismatch", diff)
+		}
+	}
+}
+
+func TestMapEntries(t *testing.T) {
+	m := MapEntries("years", map[int]string{2015: "Rabobank", 2014: "Lotto"})
+	if diff := cmp.Diff(M{"years[2014]": "Lotto", "years[2015]": "Rabobank"}, m); diff != "" {
+		t.Error(diff)
+	}
+	m = MapEntries("teams", M{"it's": 1})
+	if diff := cmp.Diff(M{"teams['it''s']": 1}, m); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestUpdateBuilderErr(t *testing.T) {
+	w := EqNamed("id", "expr")
+
+	if err := Update("cycling.cyclist_name").Set("firstname").Where(w).If(Eq("status")).Err(); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	err := Update("cycling.cyclist_name").Set("firstname").Where(w).If(Eq("status")).Existing().Err()
+	if err == nil || err.Error() != "IF conditions cannot be combined with IF EXISTS" {
+		t.Fatal("expected error got", err)
+	}
+
+	err = Update("cycling.cyclist_name").Set("firstname").Where(w).Timeout(-time.Second).Err()
+	if err == nil || err.Error() != "TIMEOUT must not be negative, got -1s" {
+		t.Fatal("expected error got", err)
+	}
+	err = Update("cycling.cyclist_name").SetMapEntries("teams", []string{"a"}).Where(w).Err()
+	if err == nil || err.Error() != "SetMapEntries teams: expected a map but got []string" {
+		t.Fatal("expected error got", err)
+	}
+	err = Update("cycling.cyclist_name").SetMapEntries("teams", map[[2]int]string{{1, 2}: "a"}).Where(w).Err()
+	if err == nil || err.Error() != "SetMapEntries teams: unsupported map key [2]int{1, 2}" {
+		t.Fatal("expected error got", err)
+	}
+}
+
+type testColor string
+
+type testBlob []byte
+
+type testUUID [16]byte
+
+func (u testUUID) String() string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+}
+
+type testStringer struct {
+	s string
+}
+
+func (s testStringer) String() string {
+	return s.s
+}
+
+type testIP []byte
+
+func (ip testIP) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3])
+}
+
+func TestCollectionLit(t *testing.T) {
+	s := "it's"
+	table := []struct {
+		V []interface{}
+		S string
+	}{
+		{V: []interface{}{"x", "it's"}, S: "{'x','it''s'}"},
+		{V: []interface{}{testColor("re'd")}, S: "{'re''d'}"},
+		{V: []interface{}{&s, (*string)(nil), nil}, S: "{'it''s',null,null}"},
+		{V: []interface{}{[]byte{0xca, 0xfe}, testBlob{0x01}}, S: "{0xcafe,0x01}"},
+		{V: []interface{}{testUUID{0xab, 0xcd}}, S: "{abcd0000-0000-0000-0000-000000000000}"},
+		{V: []interface{}{testStringer{"x'); DROP TABLE t; --"}}, S: "{'x''); DROP TABLE t; --'}"},
+		{V: []interface{}{testIP{127, 0, 0, 1}}, S: "{'127.0.0.1'}"},
+		{V: []interface{}{[]int{1, 2}, map[string]int{"b": 2, "a": 1}}, S: "{[1,2],{'a':1,'b':2}}"},
+		{V: []interface{}{time.Date(2005, 05, 05, 0, 0, 0, 0, time.UTC)}, S: "{'2005-05-05T00:00:00.000Z'}"},
+		{V: []interface{}{1, 2.5, true}, S: "{1,2.5,true}"},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(test.S, string(collectionLit('{', '}', test.V))); diff != "" {
+			t.Error(diff)
+		}
 	}
 }
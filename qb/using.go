@@ -6,6 +6,7 @@ package qb
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -25,6 +26,8 @@ type using struct {
 	ttlName       string
 	timestamp     int64
 	timestampName string
+	timeout       time.Duration
+	raw           string
 }
 
 func (u *using) TTL(d time.Duration) *using {
@@ -32,7 +35,7 @@ func (u *using) TTL(d time.Duration) *using {
 	if u.ttl == 0 {
 		u.ttl = -1
 	}
-	u.timestampName = ""
+	u.ttlName = ""
 	return u
 }
 
@@ -54,7 +57,40 @@ func (u *using) TimestampNamed(name string) *using {
 	return u
 }
 
+func (u *using) Timeout(d time.Duration) *using {
+	u.timeout = d
+	return u
+}
+
+// Raw sets a raw fragment that is written after the USING keyword, it cannot
+// be combined with the other options, see err.
+func (u *using) Raw(options string) *using {
+	u.raw = options
+	return u
+}
+
+// err returns an error if the timeout is negative or raw options are
+// combined with TTL, TIMESTAMP or TIMEOUT options.
+func (u *using) err() error {
+	if u.timeout < 0 {
+		return fmt.Errorf("TIMEOUT must not be negative, got %s", u.timeout)
+	}
+	if u.raw == "" {
+		return nil
+	}
+	if u.ttl != 0 || u.ttlName != "" || u.timestamp != 0 || u.timestampName != "" || u.timeout != 0 {
+		return errors.New("raw USING options cannot be combined with TTL, TIMESTAMP or TIMEOUT")
+	}
+	return nil
+}
+
 func (u *using) writeCql(cql *bytes.Buffer) (names []string) {
+	if u.raw != "" {
+		cql.WriteString("USING ")
+		cql.WriteString(u.raw)
+		cql.WriteByte(' ')
+	}
+
 	hasTTL := false
 
 	if u.ttl != 0 {
@@ -88,5 +124,45 @@ func (u *using) writeCql(cql *bytes.Buffer) (names []string) {
 		names = append(names, u.timestampName)
 	}
 
+	if u.timeout != 0 {
+		if hasTTL || u.timestamp != 0 || u.timestampName != "" {
+			cql.WriteString("AND TIMEOUT ")
+		} else {
+			cql.WriteString("USING TIMEOUT ")
+		}
+		cql.WriteString(durationLiteral(u.timeout))
+		cql.WriteByte(' ')
+	}
+
 	return
 }
+
+// names returns the names returned by writeCql without writing the options.
+func (u *using) names() (names []string) {
+	if u.ttl == 0 && u.ttlName != "" {
+		names = append(names, u.ttlName)
+	}
+	if u.timestamp == 0 && u.timestampName != "" {
+		names = append(names, u.timestampName)
+	}
+	return
+}
+
+// durationLiteral converts duration to CQL duration literal i.e. 200ms using
+// the largest unit that represents d exactly.
+func durationLiteral(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprint(int64(d/time.Hour), "h")
+	case d%time.Minute == 0:
+		return fmt.Sprint(int64(d/time.Minute), "m")
+	case d%time.Second == 0:
+		return fmt.Sprint(int64(d/time.Second), "s")
+	case d%time.Millisecond == 0:
+		return fmt.Sprint(int64(d/time.Millisecond), "ms")
+	case d%time.Microsecond == 0:
+		return fmt.Sprint(int64(d/time.Microsecond), "us")
+	default:
+		return fmt.Sprint(int64(d), "ns")
+	}
+}
@@ -69,6 +69,12 @@ func TestUsing(t *testing.T) {
 			S: "USING TTL ? AND TIMESTAMP ? ",
 			N: []string{"ttl", "ts"},
 		},
+		// TimestampNamed TTL
+		{
+			B: new(using).TimestampNamed("ts").TTL(time.Second),
+			S: "USING TTL 1 AND TIMESTAMP ? ",
+			N: []string{"ts"},
+		},
 		// TTLNamed Timestamp
 		{
 			B: new(using).TTLNamed("ttl").Timestamp(time.Date(2005, 05, 05, 0, 0, 0, 0, time.UTC)),
@@ -111,6 +117,56 @@ func TestUsing(t *testing.T) {
 			B: new(using).TimestampNamed("ts").Timestamp(time.Date(2005, 05, 05, 0, 0, 0, 0, time.UTC)),
 			S: "USING TIMESTAMP 1115251200000000 ",
 		},
+		// Timeout
+		{
+			B: new(using).Timeout(time.Second),
+			S: "USING TIMEOUT 1s ",
+		},
+		{
+			B: new(using).Timeout(90 * time.Second),
+			S: "USING TIMEOUT 90s ",
+		},
+		{
+			B: new(using).Timeout(2 * time.Hour),
+			S: "USING TIMEOUT 2h ",
+		},
+		{
+			B: new(using).Timeout(time.Nanosecond),
+			S: "USING TIMEOUT 1ns ",
+		},
+		// TTL Timestamp Timeout
+		{
+			B: new(using).TTL(time.Second).Timestamp(time.Date(2005, 05, 05, 0, 0, 0, 0, time.UTC)).Timeout(200 * time.Millisecond),
+			S: "USING TTL 1 AND TIMESTAMP 1115251200000000 AND TIMEOUT 200ms ",
+		},
+		// TimestampNamed Timeout
+		{
+			B: new(using).TimestampNamed("ts").Timeout(time.Second),
+			S: "USING TIMESTAMP ? AND TIMEOUT 1s ",
+			N: []string{"ts"},
+		},
+		// Raw
+		{
+			B: new(using).Raw("TTL 1 AND TIMESTAMP 2"),
+			S: "USING TTL 1 AND TIMESTAMP 2 ",
+		},
+		// TTLNamed TimestampNamed Raw
+		{
+			B: new(using).TTLNamed("ttl").TimestampNamed("ts").Raw("TTL 1"),
+			S: "USING TTL 1 USING TTL ? AND TIMESTAMP ? ",
+			N: []string{"ttl", "ts"},
+		},
+		// Raw TTL
+		{
+			B: new(using).Raw("TIMESTAMP 2").TTL(time.Second),
+			S: "USING TIMESTAMP 2 USING TTL 1 ",
+		},
+		// Raw TimestampNamed
+		{
+			B: new(using).Raw("TTL 1").TimestampNamed("ts"),
+			S: "USING TTL 1 USING TIMESTAMP ? ",
+			N: []string{"ts"},
+		},
 	}
 
 	for _, test := range table {
@@ -126,3 +182,27 @@ func TestUsing(t *testing.T) {
 		}
 	}
 }
+
+func TestUsingErr(t *testing.T) {
+	table := []struct {
+		B   *using
+		Err bool
+	}{
+		{B: new(using)},
+		{B: new(using).TTL(time.Second).TimestampNamed("ts").Timeout(time.Second)},
+		{B: new(using).Raw("TTL 1 AND TIMESTAMP 2")},
+		{B: new(using).Raw("TTL 1").TTL(time.Second), Err: true},
+		{B: new(using).Raw("TTL 1").TTLNamed("ttl"), Err: true},
+		{B: new(using).Timestamp(time.Unix(1, 0)).Raw("TTL 1"), Err: true},
+		{B: new(using).TimestampNamed("ts").Raw("TTL 1"), Err: true},
+		{B: new(using).Raw("TTL 1").Timeout(time.Second), Err: true},
+		{B: new(using).Timeout(-time.Second), Err: true},
+		{B: new(using).TTL(time.Second).Timeout(-time.Millisecond), Err: true},
+	}
+
+	for i, test := range table {
+		if err := test.B.err(); (err != nil) != test.Err {
+			t.Errorf("%d: expected error %v got %v", i, test.Err, err)
+		}
+	}
+}
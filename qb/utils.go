@@ -31,3 +31,25 @@ func (cols columns) writeCql(cql *bytes.Buffer) {
 		}
 	}
 }
+
+// withKeyspace returns table qualified with keyspace, if table is already
+// qualified the keyspace is replaced. If keyspace is empty table is returned
+// as is.
+func withKeyspace(table, keyspace string) string {
+	if keyspace == "" {
+		return table
+	}
+
+	inQuote := false
+	for i := 0; i < len(table); i++ {
+		switch table[i] {
+		case '"':
+			inQuote = !inQuote
+		case '.':
+			if !inQuote {
+				return keyspace + table[i:]
+			}
+		}
+	}
+	return keyspace + "." + table
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaInfo describes primary key and secondary indexes of a table, it's
+// used by SelectBuilder.Validate to check if WHERE clause can be served
+// without ALLOW FILTERING.
+type SchemaInfo struct {
+	PartitionKey  []string
+	ClusteringKey []string
+	Indexes       []string
+}
+
+func (s SchemaInfo) isIndexed(column string) bool {
+	for _, c := range s.Indexes {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+func indexOf(columns []string, column string) int {
+	for i, c := range columns {
+		if c == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// Validate checks the WHERE clause of the query against schema and returns an
+// error describing the first predicate that would require ALLOW FILTERING.
+// The rules are:
+//
+//   - partition key columns support = and IN, if one of them is restricted all
+//     of them must be unless an indexed column is restricted,
+//   - clustering key columns must be restricted in order, a column following
+//     a column restricted with a range can not be restricted, and require
+//     partition key to be restricted unless an indexed column is restricted,
+//   - indexed columns support =, CONTAINS and CONTAINS KEY, only one indexed
+//     column can be restricted,
+//   - other columns can not be restricted.
+//
+// Token restrictions are not checked. If ALLOW FILTERING is set Validate
+// returns nil.
+func (b *SelectBuilder) Validate(schema SchemaInfo) error {
+	if b.allowFiltering {
+		return nil
+	}
+
+	var (
+		partition = make([]bool, len(schema.PartitionKey))
+		// clustering holds 1 based index of the comparator restricting
+		// the clustering key column, 0 if not restricted
+		clustering = make([]int, len(schema.ClusteringKey))
+		slice      = make([]bool, len(schema.ClusteringKey))
+		indexed    string
+	)
+	for n, c := range b.where {
+		if strings.HasPrefix(c.column, "token(") {
+			continue
+		}
+		for _, column := range cmpColumns(c.column) {
+			if i := indexOf(schema.PartitionKey, column); i >= 0 {
+				if c.op != eq && c.op != in {
+					return fmt.Errorf("unsupported restriction on partition key column %q, query requires ALLOW FILTERING", column)
+				}
+				partition[i] = true
+				continue
+			}
+			if i := indexOf(schema.ClusteringKey, column); i >= 0 {
+				switch c.op {
+				case eq, in, lt, leq, gt, geq:
+					clustering[i] = n + 1
+					slice[i] = c.op != eq && c.op != in
+					continue
+				}
+			}
+			if schema.isIndexed(column) {
+				switch c.op {
+				case eq, cnt, cntKey:
+					if indexed != "" && indexed != column {
+						return fmt.Errorf("indexed columns %q and %q are restricted, only one index can be used, query requires ALLOW FILTERING", indexed, column)
+					}
+					indexed = column
+					continue
+				}
+				return fmt.Errorf("unsupported restriction on indexed column %q, query requires ALLOW FILTERING", column)
+			}
+			if indexOf(schema.ClusteringKey, column) >= 0 {
+				return fmt.Errorf("unsupported restriction on clustering key column %q, query requires ALLOW FILTERING", column)
+			}
+			return fmt.Errorf("column %q is neither a key nor indexed, query requires ALLOW FILTERING", column)
+		}
+	}
+
+	restricted := false
+	for _, ok := range partition {
+		restricted = restricted || ok
+	}
+	if restricted && indexed == "" {
+		for i, ok := range partition {
+			if !ok {
+				return fmt.Errorf("partition key column %q is not restricted, query requires ALLOW FILTERING", schema.PartitionKey[i])
+			}
+		}
+	}
+	for i, n := range clustering {
+		if n == 0 {
+			continue
+		}
+		if !restricted && indexed == "" {
+			return fmt.Errorf("clustering key column %q is restricted but partition key is not, query requires ALLOW FILTERING", schema.ClusteringKey[i])
+		}
+		for j := 0; j < i; j++ {
+			if clustering[j] == 0 {
+				return fmt.Errorf("clustering key column %q is restricted but preceding column %q is not, query requires ALLOW FILTERING", schema.ClusteringKey[i], schema.ClusteringKey[j])
+			}
+			// multi-column range restricts all its columns at once
+			if slice[j] && clustering[j] != n {
+				return fmt.Errorf("clustering key column %q is restricted but preceding column %q is restricted by a range, query requires ALLOW FILTERING", schema.ClusteringKey[i], schema.ClusteringKey[j])
+			}
+		}
+	}
+
+	return nil
+}
+
+// cmpColumns returns columns of a comparator, multi-column comparators i.e.
+// (a,b)>(?,?) are split into separate columns.
+func cmpColumns(column string) []string {
+	if !strings.HasPrefix(column, "(") || !strings.HasSuffix(column, ")") {
+		return []string{column}
+	}
+	columns := strings.Split(column[1:len(column)-1], ",")
+	for i := range columns {
+		columns[i] = strings.TrimSpace(columns[i])
+	}
+	return columns
+}
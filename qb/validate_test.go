@@ -0,0 +1,123 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+)
+
+func TestSelectBuilderValidate(t *testing.T) {
+	schema := SchemaInfo{
+		PartitionKey:  []string{"a", "b"},
+		ClusteringKey: []string{"c", "d"},
+		Indexes:       []string{"email", "tags"},
+	}
+
+	table := []struct {
+		B *SelectBuilder
+		E string
+	}{
+		// Full partition key
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), In("b")),
+		},
+		// Clustering key prefix
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), Eq("b"), Eq("c"), Gt("d")),
+		},
+		// Multi-column clustering key restriction
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), Eq("b"), GtTuple("(c,d)", 2)),
+		},
+		// Indexed column
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("email")),
+		},
+		// Indexed column with clustering key
+		{
+			B: Select("cycling.cyclist_name").Where(Contains("tags"), Eq("c")),
+		},
+		// Clustering key after IN
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), Eq("b"), In("c"), Gt("d")),
+		},
+		// Token
+		{
+			B: Select("cycling.cyclist_name").Where(Token("a", "b").Gt()),
+		},
+		// Allow filtering
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("name")).AllowFiltering(),
+		},
+		// Unindexed column
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), Eq("b"), Eq("name")),
+			E: `column "name" is neither a key nor indexed, query requires ALLOW FILTERING`,
+		},
+		// Range on partition key
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), Gt("b")),
+			E: `unsupported restriction on partition key column "b", query requires ALLOW FILTERING`,
+		},
+		// Range on indexed column
+		{
+			B: Select("cycling.cyclist_name").Where(Gt("email")),
+			E: `unsupported restriction on indexed column "email", query requires ALLOW FILTERING`,
+		},
+		// Contains on clustering key
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), Eq("b"), Contains("c")),
+			E: `unsupported restriction on clustering key column "c", query requires ALLOW FILTERING`,
+		},
+		// Partial partition key
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a")),
+			E: `partition key column "b" is not restricted, query requires ALLOW FILTERING`,
+		},
+		// Clustering key without partition key
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("c")),
+			E: `clustering key column "c" is restricted but partition key is not, query requires ALLOW FILTERING`,
+		},
+		// Clustering key gap
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), Eq("b"), Eq("d")),
+			E: `clustering key column "d" is restricted but preceding column "c" is not, query requires ALLOW FILTERING`,
+		},
+		// Two indexed columns
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("email"), Contains("tags")),
+			E: `indexed columns "email" and "tags" are restricted, only one index can be used, query requires ALLOW FILTERING`,
+		},
+		// Clustering key after range
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("a"), Eq("b"), Gt("c"), Eq("d")),
+			E: `clustering key column "d" is restricted but preceding column "c" is restricted by a range, query requires ALLOW FILTERING`,
+		},
+		// Clustering key after range with indexed column
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("email"), Gt("c"), Eq("d")),
+			E: `clustering key column "d" is restricted but preceding column "c" is restricted by a range, query requires ALLOW FILTERING`,
+		},
+		// Clustering key gap with indexed column
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("email"), Eq("d")),
+			E: `clustering key column "d" is restricted but preceding column "c" is not, query requires ALLOW FILTERING`,
+		},
+	}
+
+	for _, test := range table {
+		err := test.B.Validate(schema)
+		if test.E == "" {
+			if err != nil {
+				t.Error(test.B, "unexpected error", err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != test.E {
+			t.Error(test.B, "expected", test.E, "got", err)
+		}
+	}
+}
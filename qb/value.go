@@ -6,7 +6,13 @@ package qb
 
 import (
 	"bytes"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // value is a CQL value expression for use in an initializer, assignment,
@@ -15,6 +21,8 @@ type value interface {
 	// writeCql writes the bytes for this value to the buffer and returns the
 	// list of names of parameters which need substitution.
 	writeCql(cql *bytes.Buffer) (names []string)
+	// names returns the names returned by writeCql without writing the value.
+	names() []string
 }
 
 // param is a named CQL '?' parameter.
@@ -25,6 +33,10 @@ func (p param) writeCql(cql *bytes.Buffer) (names []string) {
 	return []string{string(p)}
 }
 
+func (p param) names() []string {
+	return []string{string(p)}
+}
+
 // param is a named CQL tuple '?' parameter.
 type tupleParam struct {
 	param param
@@ -46,6 +58,28 @@ func (t tupleParam) writeCql(cql *bytes.Buffer) (names []string) {
 	return
 }
 
+func (t tupleParam) names() (names []string) {
+	baseName := string(t.param) + "_"
+	for i := 0; i < t.count-1; i++ {
+		names = append(names, baseName+strconv.Itoa(i))
+	}
+	return append(names, baseName+strconv.Itoa(t.count-1))
+}
+
+// namedTupleParam is a CQL tuple of '?' parameters with custom names.
+type namedTupleParam []string
+
+func (t namedTupleParam) writeCql(cql *bytes.Buffer) (names []string) {
+	cql.WriteByte('(')
+	placeholders(cql, len(t))
+	cql.WriteByte(')')
+	return append(names, t...)
+}
+
+func (t namedTupleParam) names() []string {
+	return append([]string(nil), t...)
+}
+
 // lit is a literal CQL value.
 type lit string
 
@@ -53,3 +87,190 @@ func (l lit) writeCql(cql *bytes.Buffer) (names []string) {
 	cql.WriteString(string(l))
 	return nil
 }
+
+func (l lit) names() []string {
+	return nil
+}
+
+// collectionLit returns a literal CQL collection of values enclosed in open
+// and close brackets, values are formatted with Literal.
+func collectionLit(open, close byte, values []interface{}) lit {
+	var buf bytes.Buffer
+	buf.WriteByte(open)
+	for i, v := range values {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeLiteral(&buf, reflect.ValueOf(v))
+	}
+	buf.WriteByte(close)
+	return lit(buf.String())
+}
+
+// Literal returns v formatted as a CQL literal. Strings, including named
+// string types and fmt.Stringer byte slices i.e. net.IP, are quoted and
+// escaped, other byte slices and arrays are written as blobs, times as quoted
+// timestamps, UUIDs as is, slices as lists, maps as maps and nil values as
+// null. Values of other types are formatted with fmt and quoted.
+func Literal(v interface{}) string {
+	var buf bytes.Buffer
+	writeLiteral(&buf, reflect.ValueOf(v))
+	return buf.String()
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+func writeLiteral(buf *bytes.Buffer, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			buf.WriteString("null")
+			return
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return
+	}
+
+	if v.Type() == timeType {
+		buf.WriteString(quote(v.Interface().(time.Time).Format("2006-01-02T15:04:05.000Z0700")))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		buf.WriteString(quote(v.String()))
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		buf.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32:
+		buf.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		buf.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			writeBytes(buf, v)
+			return
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeLiteral(buf, v.Index(i))
+		}
+		buf.WriteByte(']')
+	case reflect.Map:
+		type entry struct {
+			k, v reflect.Value
+			s    string
+		}
+		entries := make([]entry, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			var kb bytes.Buffer
+			writeLiteral(&kb, k)
+			entries = append(entries, entry{k: k, v: v.MapIndex(k), s: kb.String()})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].s < entries[j].s
+		})
+		buf.WriteByte('{')
+		for i, e := range entries {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(e.s)
+			buf.WriteByte(':')
+			writeLiteral(buf, e.v)
+		}
+		buf.WriteByte('}')
+	default:
+		buf.WriteString(quote(fmt.Sprint(v.Interface())))
+	}
+}
+
+// writeBytes writes a byte slice or array v. UUIDs, that is 16 byte arrays
+// formatted by String as UUIDs, are written as is, other fmt.Stringer values
+// i.e. net.IP are quoted, the rest is written as a blob.
+func writeBytes(buf *bytes.Buffer, v reflect.Value) {
+	if v.Type().Implements(stringerType) {
+		s := v.Interface().(fmt.Stringer).String()
+		if v.Kind() == reflect.Array && v.Len() == 16 && isUUID(s) {
+			buf.WriteString(s)
+		} else {
+			buf.WriteString(quote(s))
+		}
+		return
+	}
+
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	buf.WriteString("0x")
+	buf.WriteString(hex.EncodeToString(b))
+}
+
+// isUUID returns true if s is a UUID in the canonical 8-4-4-4-12 hex digits
+// form.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if c != '-' {
+				return false
+			}
+		case '0' <= c && c <= '9', 'a' <= c && c <= 'f', 'A' <= c && c <= 'F':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// scalarLiteral returns v formatted by Literal, it returns false if v
+// is not a value of a scalar CQL type i.e. it's nil, a collection or
+// a struct.
+func scalarLiteral(v interface{}) (string, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || rv.Kind() == reflect.Ptr {
+		return "", false
+	}
+
+	ok := false
+	switch rv.Interface().(type) {
+	case time.Time, []byte, fmt.Stringer:
+		ok = true
+	default:
+		switch rv.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			ok = true
+		case reflect.Slice:
+			ok = rv.Type().Elem().Kind() == reflect.Uint8
+		}
+	}
+	if !ok {
+		return "", false
+	}
+
+	return Literal(v), true
+}
+
+// quote returns s as a quoted CQL string literal.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// CREATE MATERIALIZED VIEW reference:
+// https://cassandra.apache.org/doc/latest/cql/mvs.html#create-materialized-view
+
+import (
+	"bytes"
+)
+
+// CreateMaterializedViewBuilder builds CQL CREATE MATERIALIZED VIEW
+// statements.
+type CreateMaterializedViewBuilder struct {
+	view        string
+	table       string
+	keyspace    string
+	columns     columns
+	where       where
+	partKey     columns
+	sortKey     columns
+	ifNotExists bool
+}
+
+// CreateMaterializedView returns a new CreateMaterializedViewBuilder with the
+// given view name and base table name.
+func CreateMaterializedView(view, table string) *CreateMaterializedViewBuilder {
+	return &CreateMaterializedViewBuilder{
+		view:  view,
+		table: table,
+	}
+}
+
+// ToCql builds the query into a CQL string, names are always empty.
+func (b *CreateMaterializedViewBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("CREATE MATERIALIZED VIEW ")
+	if b.ifNotExists {
+		cql.WriteString("IF NOT EXISTS ")
+	}
+	cql.WriteString(withKeyspace(b.view, b.keyspace))
+	cql.WriteString(" AS SELECT ")
+	if len(b.columns) == 0 {
+		cql.WriteByte('*')
+	} else {
+		b.columns.writeCql(&cql)
+	}
+	cql.WriteString(" FROM ")
+	cql.WriteString(withKeyspace(b.table, b.keyspace))
+	cql.WriteByte(' ')
+
+	b.where.writeCql(&cql)
+
+	cql.WriteString("PRIMARY KEY (")
+	if len(b.partKey) > 1 {
+		cql.WriteByte('(')
+		b.partKey.writeCql(&cql)
+		cql.WriteByte(')')
+	} else {
+		b.partKey.writeCql(&cql)
+	}
+	if len(b.sortKey) > 0 {
+		cql.WriteByte(',')
+		b.sortKey.writeCql(&cql)
+	}
+	cql.WriteString(") ")
+
+	stmt = cql.String()
+	return
+}
+
+// WithKeyspace sets the keyspace of the view and the base table, it replaces
+// the keyspace the names are qualified with, if any, when the query is built.
+func (b *CreateMaterializedViewBuilder) WithKeyspace(keyspace string) *CreateMaterializedViewBuilder {
+	b.keyspace = keyspace
+	return b
+}
+
+// Columns adds selected columns to the view, if no columns are added all
+// the columns are selected.
+func (b *CreateMaterializedViewBuilder) Columns(columns ...string) *CreateMaterializedViewBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+// Where adds an expression to the WHERE clause of the view, expressions must
+// not have parameters. Expressions are ANDed together in the generated CQL.
+func (b *CreateMaterializedViewBuilder) Where(w ...Cmp) *CreateMaterializedViewBuilder {
+	b.where = append(b.where, w...)
+	return b
+}
+
+// NotNull adds IS NOT NULL restrictions for the columns to the WHERE clause of
+// the view, see IsNotNull. Every primary key column of the view must be
+// restricted.
+func (b *CreateMaterializedViewBuilder) NotNull(columns ...string) *CreateMaterializedViewBuilder {
+	for _, c := range columns {
+		b.where = append(b.where, IsNotNull(c))
+	}
+	return b
+}
+
+// PartKey adds partition key columns to the view primary key.
+func (b *CreateMaterializedViewBuilder) PartKey(columns ...string) *CreateMaterializedViewBuilder {
+	b.partKey = append(b.partKey, columns...)
+	return b
+}
+
+// SortKey adds clustering columns to the view primary key.
+func (b *CreateMaterializedViewBuilder) SortKey(columns ...string) *CreateMaterializedViewBuilder {
+	b.sortKey = append(b.sortKey, columns...)
+	return b
+}
+
+// IfNotExists sets a IF NOT EXISTS clause on the query.
+func (b *CreateMaterializedViewBuilder) IfNotExists() *CreateMaterializedViewBuilder {
+	b.ifNotExists = true
+	return b
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateMaterializedViewBuilder(t *testing.T) {
+	table := []struct {
+		B *CreateMaterializedViewBuilder
+		S string
+	}{
+		// Basic test for create materialized view
+		{
+			B: CreateMaterializedView("cycling.cyclist_by_age", "cycling.cyclist_name").
+				NotNull("age", "id").PartKey("age").SortKey("id"),
+			S: "CREATE MATERIALIZED VIEW cycling.cyclist_by_age AS SELECT * FROM cycling.cyclist_name WHERE age IS NOT NULL AND id IS NOT NULL PRIMARY KEY (age,id) ",
+		},
+		// Add columns
+		{
+			B: CreateMaterializedView("cycling.cyclist_by_age", "cycling.cyclist_name").
+				Columns("age", "id", "firstname").NotNull("age", "id").PartKey("age").SortKey("id"),
+			S: "CREATE MATERIALIZED VIEW cycling.cyclist_by_age AS SELECT age,id,firstname FROM cycling.cyclist_name WHERE age IS NOT NULL AND id IS NOT NULL PRIMARY KEY (age,id) ",
+		},
+		// Composite partition key
+		{
+			B: CreateMaterializedView("cycling.cyclist_by_country", "cycling.cyclist_name").
+				NotNull("country", "age", "id").PartKey("country", "age").SortKey("id"),
+			S: "CREATE MATERIALIZED VIEW cycling.cyclist_by_country AS SELECT * FROM cycling.cyclist_name WHERE country IS NOT NULL AND age IS NOT NULL AND id IS NOT NULL PRIMARY KEY ((country,age),id) ",
+		},
+		// Add WHERE
+		{
+			B: CreateMaterializedView("cycling.cyclist_by_age", "cycling.cyclist_name").
+				Where(IsNotNull("age"), IsNotNull("id"), GtLit("age", "18")).PartKey("age").SortKey("id"),
+			S: "CREATE MATERIALIZED VIEW cycling.cyclist_by_age AS SELECT * FROM cycling.cyclist_name WHERE age IS NOT NULL AND id IS NOT NULL AND age>18 PRIMARY KEY (age,id) ",
+		},
+		// Add IF NOT EXISTS
+		{
+			B: CreateMaterializedView("cycling.cyclist_by_age", "cycling.cyclist_name").
+				NotNull("age", "id").PartKey("age").SortKey("id").IfNotExists().WithKeyspace("tenant"),
+			S: "CREATE MATERIALIZED VIEW IF NOT EXISTS tenant.cyclist_by_age AS SELECT * FROM tenant.cyclist_name WHERE age IS NOT NULL AND id IS NOT NULL PRIMARY KEY (age,id) ",
+		},
+		{
+			B: CreateMaterializedView("cycling.cyclist_by_age", "cycling.cyclist_name").
+				NotNull("age", "id").PartKey("age").SortKey("id").IfNotExists(),
+			S: "CREATE MATERIALIZED VIEW IF NOT EXISTS cycling.cyclist_by_age AS SELECT * FROM cycling.cyclist_name WHERE age IS NOT NULL AND id IS NOT NULL PRIMARY KEY (age,id) ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if len(names) != 0 {
+			t.Error("expected no names got", names)
+		}
+	}
+}
@@ -9,10 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/scylladb/go-reflectx"
+	"github.com/scylladb/gocqlx/qb"
 )
 
 // CompileNamedQuery translates query with named parameters in a form
@@ -84,9 +87,30 @@ func allowedBindRune(b byte) bool {
 // Queryx is a wrapper around gocql.Query which adds struct binding capabilities.
 type Queryx struct {
 	*gocql.Query
-	Names  []string
-	Mapper *reflectx.Mapper
-	err    error
+	Names    []string
+	Mapper   *reflectx.Mapper
+	err      error
+	strict   bool
+	zeroTime zeroTimeMode
+
+	// Set by Session.Query, passed to Iterx, see Iterx.IgnoreCase and
+	// Iterx.NullPolicy.
+	ignoreCase bool
+	nullPolicy NullPolicy
+
+	// Set by FieldFilter or Session.Query, passed to Iterx.
+	filter FieldFilter
+
+	// Set by Session.Query, see Session.WithLargePartitionHandler.
+	largePartition LargePartitionHandler
+
+	// Set by Session.Query, used to fetch trace events.
+	session *gocql.Session
+	tracer  *traceCollector
+
+	// Stats of the most recent execution, see LastAttempts and LastLatency.
+	lastAttempts int
+	lastLatency  int64
 }
 
 // Query creates a new Queryx from gocql.Query using a default mapper.
@@ -94,14 +118,132 @@ func Query(q *gocql.Query, names []string) *Queryx {
 	return &Queryx{
 		Query:  q,
 		Names:  names,
-		Mapper: DefaultMapper,
+		Mapper: defaultMapper(),
+	}
+}
+
+// Release returns the underlying gocql.Query to gocql for reuse, the bound
+// values are reset so that they do not leak to the queries reusing it, this
+// reduces allocations and GC pressure in high-throughput services. The query
+// cannot be bound or executed after Release, doing so reports
+// ErrQueryReleased, and values returned by it i.e. iterators cannot be used.
+// The results of the most recent execution like TraceEvents, LastAttempts and
+// LastLatency are still available. Calling Release more than once is a no-op,
+// the *Release functions like ExecRelease call Release after executing the
+// query.
+func (q *Queryx) Release() {
+	if q.Query == nil {
+		return
 	}
+	q.Query.Release()
+	q.Query = nil
+	q.err = ErrQueryReleased
+}
+
+// Strict forces BindMap and BindStructMap to report an error if the map
+// contains keys that are not referenced by any of the query named parameters,
+// this helps catching typos in map keys. BindStructMap also reports keys that
+// are shadowed by struct fields, including fields of embedded structs.
+func (q *Queryx) Strict() *Queryx {
+	q.strict = true
+	return q
+}
+
+// zeroTimeMode specifies how zero time.Time values are bound.
+type zeroTimeMode byte
+
+const (
+	zeroTimeValue zeroTimeMode = iota
+	zeroTimeNull
+	zeroTimeUnset
+)
+
+// ZeroTimeAsNull makes the query bind zero time.Time values, and pointers to
+// them, as null instead of the zero time, which for timestamp columns is
+// stored as a date in year 1.
+func (q *Queryx) ZeroTimeAsNull() *Queryx {
+	q.zeroTime = zeroTimeNull
+	return q
+}
+
+// ZeroTimeAsUnset is like ZeroTimeAsNull but binds zero time.Time values as
+// gocql.UnsetValue leaving the columns untouched, see BindStructUnsetEmpty.
+func (q *Queryx) ZeroTimeAsUnset() *Queryx {
+	q.zeroTime = zeroTimeUnset
+	return q
+}
+
+// zeroTimeValues returns v with zero time.Time values replaced according to
+// mode, v is copied if modified.
+func zeroTimeValues(v []interface{}, mode zeroTimeMode) []interface{} {
+	if mode == zeroTimeValue {
+		return v
+	}
+
+	var r interface{}
+	if mode == zeroTimeUnset {
+		r = gocql.UnsetValue
+	}
+
+	copied := false
+	for i, a := range v {
+		var zero bool
+		switch t := a.(type) {
+		case time.Time:
+			zero = t.IsZero()
+		case *time.Time:
+			zero = t != nil && t.IsZero()
+		}
+		if !zero {
+			continue
+		}
+		if !copied {
+			v = append([]interface{}(nil), v...)
+			copied = true
+		}
+		v[i] = r
+	}
+	return v
+}
+
+// FieldFilter sets a filter deciding which struct fields are used by the Bind
+// functions and by the iterators returned by the query, see FieldFilter type.
+func (q *Queryx) FieldFilter(filter FieldFilter) *Queryx {
+	q.filter = filter
+	return q
 }
 
 // BindStruct binds query named parameters to values from arg using mapper. If
-// value cannot be found error is reported.
-func (q *Queryx) BindStruct(arg interface{}) *Queryx {
-	arglist, err := bindStructArgs(q.Names, arg, nil, q.Mapper)
+// value cannot be found error is reported. If arg implements RowMarshaler the
+// values are returned by MarshalRow.
+//
+// Optional overrides take precedence over the fields of arg, this allows to
+// bind a struct together with computed values in a single call i.e.
+// BindStruct(p, qb.M{"updated_at": time.Now()}). If several overrides
+// contain the same name the last one wins. Override values are converted like
+// map values i.e. qb.Unset is bound as unset. If arg implements RowMarshaler
+// MarshalRow is called with the names that are not overridden.
+func (q *Queryx) BindStruct(arg interface{}, overrides ...map[string]interface{}) *Queryx {
+	if len(overrides) > 0 {
+		return q.bindStructOverrides(arg, mergeMaps(overrides))
+	}
+
+	arglist, err := q.bindStructArgs(arg, nil)
+	if err != nil {
+		q.err = fmt.Errorf("bind error: %s", err)
+	} else {
+		q.err = nil
+		q.Bind(arglist...)
+	}
+
+	return q
+}
+
+func (q *Queryx) bindStructOverrides(arg interface{}, overrides map[string]interface{}) *Queryx {
+	arglist, err := bindStructOverrideArgs(q.Names, arg, overrides, q.Mapper, q.filter)
+	if err == nil && q.strict {
+		err = unusedKeys(q.Names, overrides)
+	}
 	if err != nil {
 		q.err = fmt.Errorf("bind error: %s", err)
 	} else {
@@ -112,11 +254,107 @@ func (q *Queryx) BindStruct(arg interface{}) *Queryx {
 	return q
 }
 
+// bindStructOverrideArgs is like bindStructArgsFilter but values from
+// overrides take precedence over the fields of arg.
+func bindStructOverrideArgs(names []string, arg interface{}, overrides map[string]interface{}, m *reflectx.Mapper, filter FieldFilter) ([]interface{}, error) {
+	arglist, err := bindStructArgsFilter(names, arg, overrides, m, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		if v, ok := overrides[name]; ok {
+			arglist[i] = mapValue(v)
+		}
+	}
+	return arglist, nil
+}
+
+// marshalRowOverrides returns values from MarshalRow for names that are not
+// in overrides, the overridden values are left nil.
+func marshalRowOverrides(rm RowMarshaler, names []string, overrides map[string]interface{}) ([]interface{}, error) {
+	var rest []string
+	for _, name := range names {
+		if _, ok := overrides[name]; !ok {
+			rest = append(rest, name)
+		}
+	}
+	values, err := rm.MarshalRow(rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(rest) {
+		return nil, fmt.Errorf("MarshalRow returned %d values for %d names", len(values), len(rest))
+	}
+
+	arglist := make([]interface{}, len(names))
+	j := 0
+	for i, name := range names {
+		if _, ok := overrides[name]; !ok {
+			arglist[i] = values[j]
+			j++
+		}
+	}
+	return arglist, nil
+}
+
+// mergeMaps returns a map with keys of all the maps, values of the later maps
+// take precedence.
+func mergeMaps(maps []map[string]interface{}) map[string]interface{} {
+	if len(maps) == 1 {
+		return maps[0]
+	}
+	out := make(map[string]interface{})
+	for _, m := range maps {
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// BindStructUnsetEmpty is like BindStruct but binds zero valued fields as
+// gocql.UnsetValue, so that only the populated fields are written. Binding
+// zero values explicitly overwrites existing data, and binding null creates
+// a tombstone, unset values are ignored by the database leaving the columns
+// untouched. Note that primary key columns cannot be unset and that legit
+// zero values i.e. 0 or empty string are unset as well. Unset values require
+// protocol version 4 or newer.
+func (q *Queryx) BindStructUnsetEmpty(arg interface{}) *Queryx {
+	arglist, err := q.bindStructArgs(arg, nil)
+	if err != nil {
+		q.err = fmt.Errorf("bind error: %s", err)
+	} else {
+		q.err = nil
+		q.Bind(unsetEmpty(arglist)...)
+	}
+
+	return q
+}
+
+// unsetEmpty replaces zero values in arglist with gocql.UnsetValue.
+func unsetEmpty(arglist []interface{}) []interface{} {
+	for i, v := range arglist {
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			arglist[i] = gocql.UnsetValue
+		}
+	}
+	return arglist
+}
+
 // BindStructMap binds query named parameters to values from arg0 and arg1
 // using a mapper. If value cannot be found in arg0 it's looked up in arg1
-// before reporting an error.
+// before reporting an error. In strict mode keys of arg1 that are not used,
+// because there is no such name or because the name is bound from a field of
+// arg0, are reported as an error. If arg0 implements RowMarshaler MarshalRow
+// is called with the names that are not in arg1.
 func (q *Queryx) BindStructMap(arg0 interface{}, arg1 map[string]interface{}) *Queryx {
-	arglist, err := bindStructArgs(q.Names, arg0, arg1, q.Mapper)
+	arglist, err := q.bindStructArgs(arg0, arg1)
+	if err == nil && q.strict {
+		err = unusedKeys(q.Names, arg1)
+	}
+	if err == nil && q.strict {
+		err = shadowedKeys(q.Names, arg0, arg1, q.Mapper, q.filter)
+	}
 	if err != nil {
 		q.err = fmt.Errorf("bind error: %s", err)
 	} else {
@@ -127,7 +365,73 @@ func (q *Queryx) BindStructMap(arg0 interface{}, arg1 map[string]interface{}) *Q
 	return q
 }
 
+// BindStructMapErr is like BindStructMap but all the names that cannot be
+// resolved from neither arg0 nor arg1 are reported in a single error, which
+// is also returned. This helps catching refactoring mistakes when a column is
+// renamed in the query but not in the struct.
+func (q *Queryx) BindStructMapErr(arg0 interface{}, arg1 map[string]interface{}) error {
+	if missing := missingNames(q.Names, arg0, arg1, q.Mapper, q.filter); len(missing) > 0 {
+		q.err = fmt.Errorf("bind error: could not find names %q in %T and map", missing, arg0)
+		return q.err
+	}
+	return q.BindStructMap(arg0, arg1).err
+}
+
+// missingNames returns names that are neither mapped to a field of arg0 nor
+// are keys of arg1.
+func missingNames(names []string, arg0 interface{}, arg1 map[string]interface{}, m *reflectx.Mapper, filter FieldFilter) []string {
+	if _, ok := arg0.(RowMarshaler); ok {
+		return nil
+	}
+	// nil arg0 has no fields
+	traversals := make([][]int, len(names))
+	if v := reflect.Indirect(reflect.ValueOf(arg0)); v.Kind() == reflect.Struct {
+		traversals = filterTraversals(v.Type(), m.TraversalsByName(v.Type(), names), filter)
+	}
+
+	var missing []string
+	for i, traversal := range traversals {
+		if len(traversal) != 0 {
+			continue
+		}
+		if _, ok := arg1[names[i]]; !ok {
+			missing = append(missing, names[i])
+		}
+	}
+	return missing
+}
+
+// marshalRowMap returns values from MarshalRow for names that are not in
+// arg1, the other names are bound from arg1.
+func marshalRowMap(rm RowMarshaler, names []string, arg1 map[string]interface{}) ([]interface{}, error) {
+	if len(arg1) == 0 {
+		return rm.MarshalRow(names)
+	}
+	arglist, err := marshalRowOverrides(rm, names, arg1)
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		if v, ok := arg1[name]; ok {
+			arglist[i] = mapValue(v)
+		}
+	}
+	return arglist, nil
+}
+
+func (q *Queryx) bindStructArgs(arg0 interface{}, arg1 map[string]interface{}) ([]interface{}, error) {
+	return bindStructArgsFilter(q.Names, arg0, arg1, q.Mapper, q.filter)
+}
+
 func bindStructArgs(names []string, arg0 interface{}, arg1 map[string]interface{}, m *reflectx.Mapper) ([]interface{}, error) {
+	return bindStructArgsFilter(names, arg0, arg1, m, nil)
+}
+
+func bindStructArgsFilter(names []string, arg0 interface{}, arg1 map[string]interface{}, m *reflectx.Mapper, filter FieldFilter) ([]interface{}, error) {
+	if rm, ok := arg0.(RowMarshaler); ok {
+		return marshalRowMap(rm, names, arg1)
+	}
+
 	arglist := make([]interface{}, 0, len(names))
 
 	// grab the indirected value of arg
@@ -136,7 +440,25 @@ func bindStructArgs(names []string, arg0 interface{}, arg1 map[string]interface{
 		v = v.Elem()
 	}
 
+	// nil arg has no fields, names can only be bound from arg1
+	if !v.IsValid() {
+		for _, name := range names {
+			val, ok := arg1[name]
+			if !ok {
+				return nil, fmt.Errorf("could not find name %q in %#v and %#v", name, arg0, arg1)
+			}
+			arglist = append(arglist, mapValue(val))
+		}
+		return arglist, nil
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct or a struct pointer, got %T", arg0)
+	}
+
 	err := m.TraversalsByNameFunc(v.Type(), names, func(i int, t []int) error {
+		if filter != nil && len(t) != 0 && !allowedTraversal(v.Type(), t, filter) { // nolint:scopelint
+			t = nil
+		}
 		if len(t) != 0 {
 			val := reflectx.FieldByIndexesReadOnly(v, t) // nolint:scopelint
 			arglist = append(arglist, val.Interface())
@@ -145,7 +467,7 @@ func bindStructArgs(names []string, arg0 interface{}, arg1 map[string]interface{
 			if !ok {
 				return fmt.Errorf("could not find name %q in %#v and %#v", names[i], arg0, arg1)
 			}
-			arglist = append(arglist, val)
+			arglist = append(arglist, mapValue(val))
 		}
 
 		return nil
@@ -157,6 +479,9 @@ func bindStructArgs(names []string, arg0 interface{}, arg1 map[string]interface{
 // BindMap binds query named parameters using map.
 func (q *Queryx) BindMap(arg map[string]interface{}) *Queryx {
 	arglist, err := bindMapArgs(q.Names, arg)
+	if err == nil && q.strict {
+		err = unusedKeys(q.Names, arg)
+	}
 	if err != nil {
 		q.err = fmt.Errorf("bind error: %s", err)
 	} else {
@@ -175,21 +500,86 @@ func bindMapArgs(names []string, arg map[string]interface{}) ([]interface{}, err
 		if !ok {
 			return arglist, fmt.Errorf("could not find name %q in %#v", name, arg)
 		}
-		arglist = append(arglist, val)
+		arglist = append(arglist, mapValue(val))
 	}
 	return arglist, nil
 }
 
+// mapValue replaces qb.Unset with gocql.UnsetValue.
+func mapValue(v interface{}) interface{} {
+	if v == qb.Unset {
+		return gocql.UnsetValue
+	}
+	return v
+}
+
+// unusedKeys returns an error listing keys of arg that are not in names.
+func unusedKeys(names []string, arg map[string]interface{}) error {
+	used := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		used[name] = struct{}{}
+	}
+
+	var unused []string
+	for k := range arg {
+		if _, ok := used[k]; !ok {
+			unused = append(unused, k)
+		}
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+	sort.Strings(unused)
+
+	return fmt.Errorf("unused map keys %q", unused)
+}
+
+// shadowedKeys returns an error listing keys of arg1 that are not used
+// because the names are bound from fields of arg0, including fields promoted
+// from embedded structs.
+func shadowedKeys(names []string, arg0 interface{}, arg1 map[string]interface{}, m *reflectx.Mapper, filter FieldFilter) error {
+	if _, ok := arg0.(RowMarshaler); ok {
+		return nil
+	}
+	v := reflect.Indirect(reflect.ValueOf(arg0))
+	if len(arg1) == 0 || v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var shadowed []string
+	for i, t := range m.TraversalsByName(v.Type(), names) {
+		if len(t) == 0 || filter != nil && !allowedTraversal(v.Type(), t, filter) {
+			continue
+		}
+		if _, ok := arg1[names[i]]; ok {
+			shadowed = append(shadowed, names[i])
+		}
+	}
+	if len(shadowed) == 0 {
+		return nil
+	}
+	sort.Strings(shadowed)
+
+	return fmt.Errorf("map keys %q are shadowed by fields of %T", shadowed, arg0)
+}
+
 // Err returns any binding errors.
 func (q *Queryx) Err() error {
 	return q.err
 }
 
-// Exec executes the query without returning any rows.
+// Exec executes the query without returning any rows. Large partition
+// warnings are reported to the session handler if set, see
+// Session.WithLargePartitionHandler.
 func (q *Queryx) Exec() error {
 	if q.err != nil {
 		return q.err
 	}
+	defer q.trackStats()()
+	if q.largePartition != nil {
+		_, err := q.ExecWithResult()
+		return err
+	}
 	return q.Query.Exec()
 }
 
@@ -217,6 +607,7 @@ func (q *Queryx) Get(dest interface{}) error {
 	if q.err != nil {
 		return q.err
 	}
+	defer q.trackStats()()
 	return q.Iter().Get(dest)
 }
 
@@ -227,6 +618,64 @@ func (q *Queryx) GetRelease(dest interface{}) error {
 	return q.Get(dest)
 }
 
+// GetCAS executes a conditional (lightweight transaction) statement and
+// returns true if it was applied. If the statement was not applied the
+// existing row is scanned into dest, which must be a struct pointer.
+// See Iterx.GetCAS.
+func (q *Queryx) GetCAS(dest interface{}) (applied bool, err error) {
+	if q.err != nil {
+		return false, q.err
+	}
+	defer q.trackStats()()
+	return q.Iter().GetCAS(dest)
+}
+
+// GetCASRelease calls GetCAS and releases the query, a released query cannot
+// be reused.
+func (q *Queryx) GetCASRelease(dest interface{}) (applied bool, err error) {
+	defer q.Release()
+	return q.GetCAS(dest)
+}
+
+// ScanCAS executes a conditional (lightweight transaction) statement and
+// returns true if it was applied. If the statement was not applied the
+// existing row values are scanned into dest, one for every column following
+// the [applied] column. To scan into a struct use GetCAS.
+func (q *Queryx) ScanCAS(dest ...interface{}) (applied bool, err error) {
+	if q.err != nil {
+		return false, q.err
+	}
+	defer q.trackStats()()
+	return q.Query.ScanCAS(dest...)
+}
+
+// ScanCASRelease calls ScanCAS and releases the query, a released query
+// cannot be reused.
+func (q *Queryx) ScanCASRelease(dest ...interface{}) (applied bool, err error) {
+	defer q.Release()
+	return q.ScanCAS(dest...)
+}
+
+// ExecBatchCAS executes a conditional batch statement i.e. built with
+// qb.Batch from conditional statements, and returns true if it was applied.
+// If the batch was not applied the existing rows, one for every conditional
+// statement, are selected into dest, which must be a pointer to slice of
+// structs. See Iterx.SelectCAS.
+func (q *Queryx) ExecBatchCAS(dest interface{}) (applied bool, err error) {
+	if q.err != nil {
+		return false, q.err
+	}
+	defer q.trackStats()()
+	return q.Iter().SelectCAS(dest)
+}
+
+// ExecBatchCASRelease calls ExecBatchCAS and releases the query, a released
+// query cannot be reused.
+func (q *Queryx) ExecBatchCASRelease(dest interface{}) (applied bool, err error) {
+	defer q.Release()
+	return q.ExecBatchCAS(dest)
+}
+
 // Select scans all rows into a destination, which must be a pointer to slice
 // of any type, and closes the iterator.
 //
@@ -245,6 +694,7 @@ func (q *Queryx) Select(dest interface{}) error {
 	if q.err != nil {
 		return q.err
 	}
+	defer q.trackStats()()
 	return q.Iter().Select(dest)
 }
 
@@ -255,11 +705,51 @@ func (q *Queryx) SelectRelease(dest interface{}) error {
 	return q.Select(dest)
 }
 
+// LastAttempts returns the number of times the query was executed by the most
+// recent call to Exec, ExecWithResult, Get, Select or one of the CAS methods.
+// Unlike Attempts of the embedded gocql.Query it's not accumulated across
+// executions. It's available after the query is released.
+func (q *Queryx) LastAttempts() int {
+	return q.lastAttempts
+}
+
+// LastLatency returns the average amount of nanoseconds per attempt of the
+// most recent call to Exec, ExecWithResult, Get, Select or one of the CAS
+// methods. It's available after the query is released.
+func (q *Queryx) LastLatency() int64 {
+	return q.lastLatency
+}
+
+// trackStats returns a function that records attempts and latency of the
+// query execution started when trackStats was called. gocql accumulates the
+// metrics across executions of the query, so the difference is recorded.
+func (q *Queryx) trackStats() func() {
+	attempts, latency := q.metrics()
+	return func() {
+		a, l := q.metrics()
+		q.lastAttempts = a - attempts
+		q.lastLatency = 0
+		if q.lastAttempts > 0 {
+			q.lastLatency = (l - latency) / int64(q.lastAttempts)
+		}
+	}
+}
+
+// metrics returns the total number of attempts and the total latency of the
+// query.
+func (q *Queryx) metrics() (attempts int, latency int64) {
+	attempts = q.Query.Attempts()
+	return attempts, q.Query.Latency() * int64(attempts)
+}
+
 // Iter returns Iterx instance for the query. It should be used when data is too
 // big to be loaded with Select in order to do row by row iteration.
 // See Iterx StructScan function.
 func (q *Queryx) Iter() *Iterx {
 	i := Iter(q.Query)
 	i.Mapper = q.Mapper
+	i.ignoreCase = q.ignoreCase
+	i.nullPolicy = q.nullPolicy
+	i.filter = q.filter
 	return i
 }
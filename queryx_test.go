@@ -5,9 +5,15 @@
 package gocqlx
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gocql/gocql"
 	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/qb"
 )
 
 func TestCompileQuery(t *testing.T) {
@@ -109,6 +115,91 @@ func TestBindStruct(t *testing.T) {
 		}
 	})
 
+	t.Run("fallback unset", func(t *testing.T) {
+		names := []string{"name", "age", "first", "not_found"}
+		args, err := bindStructArgs(names, v, qb.M{"not_found": qb.Unset}, DefaultMapper)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []interface{}{"name", 30, "first", gocql.UnsetValue}
+		if diff := cmp.Diff(args, expected); diff != "" {
+			t.Error("args mismatch", diff)
+		}
+	})
+
+	t.Run("unset empty", func(t *testing.T) {
+		v := &struct {
+			Name  string
+			Age   int
+			First *string
+			Tags  []string
+			Props map[string]string
+		}{
+			Name: "name",
+			Tags: []string{"tag"},
+		}
+		names := []string{"name", "age", "first", "tags", "props"}
+		args, err := bindStructArgs(names, v, nil, DefaultMapper)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []interface{}{"name", gocql.UnsetValue, gocql.UnsetValue, []string{"tag"}, gocql.UnsetValue}
+		if diff := cmp.Diff(unsetEmpty(args), expected); diff != "" {
+			t.Error("args mismatch", diff)
+		}
+
+		q := Query(&gocql.Query{}, names).BindStructUnsetEmpty(v)
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		names := []string{"name", "age", "first", "not_found"}
+		args, err := bindStructOverrideArgs(names, v, qb.M{"age": 31, "not_found": "last"}, DefaultMapper, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(args, []interface{}{"name", 31, "first", "last"}); diff != "" {
+			t.Error("args mismatch", diff)
+		}
+
+		args, err = bindStructOverrideArgs(names, v, qb.M{"age": qb.Unset, "not_found": qb.Unset}, DefaultMapper, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(args, []interface{}{"name", gocql.UnsetValue, "first", gocql.UnsetValue}); diff != "" {
+			t.Error("args mismatch", diff)
+		}
+
+		q := Query(&gocql.Query{}, names).BindStruct(v, qb.M{"not_found": "last"})
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("overrides merge", func(t *testing.T) {
+		if diff := cmp.Diff(mergeMaps([]map[string]interface{}{{"a": 1, "b": 2}, {"b": 3}}), map[string]interface{}{"a": 1, "b": 3}); diff != "" {
+			t.Error("merge mismatch", diff)
+		}
+	})
+
+	t.Run("overrides error", func(t *testing.T) {
+		names := []string{"name", "not_found"}
+		q := Query(&gocql.Query{}, names).BindStruct(v, qb.M{"age": 31})
+		if err := q.Err(); err == nil {
+			t.Fatal("expected bind error")
+		}
+
+		q = Query(&gocql.Query{}, []string{"name"}).Strict().BindStruct(v, qb.M{"age": 31})
+		if err := q.Err(); err == nil || !strings.Contains(err.Error(), "age") {
+			t.Fatal("expected unused keys error got", err)
+		}
+	})
+
 	t.Run("fallback error", func(t *testing.T) {
 		names := []string{"name", "age", "first", "not_found", "really_not_found"}
 		m := map[string]interface{}{
@@ -141,6 +232,17 @@ func TestBindMap(t *testing.T) {
 		}
 	})
 
+	t.Run("unset", func(t *testing.T) {
+		names := []string{"name", "age"}
+		args, err := bindMapArgs(names, qb.M{"name": "name", "age": qb.Unset})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if args[0] != "name" || args[1] != gocql.UnsetValue {
+			t.Fatal("expected age to be unset got", args)
+		}
+	})
+
 	t.Run("error", func(t *testing.T) {
 		names := []string{"name", "first", "not_found"}
 		_, err := bindMapArgs(names, v)
@@ -149,3 +251,394 @@ func TestBindMap(t *testing.T) {
 		}
 	})
 }
+
+func TestStrict(t *testing.T) {
+	v := &struct {
+		Name string
+	}{
+		Name: "name",
+	}
+	names := []string{"name", "email"}
+
+	t.Run("bind struct map", func(t *testing.T) {
+		q := Query(&gocql.Query{}, names).Strict().BindStructMap(v, map[string]interface{}{
+			"email": "email",
+		})
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("bind struct map error", func(t *testing.T) {
+		q := Query(&gocql.Query{}, names).Strict().BindStructMap(v, map[string]interface{}{
+			"email":    "email",
+			"new_emai": "email",
+		})
+		if err := q.Err(); err == nil || err.Error() != `bind error: unused map keys ["new_emai"]` {
+			t.Fatal("expected unused keys error got", err)
+		}
+	})
+
+	t.Run("bind struct map shadowed", func(t *testing.T) {
+		type Embedded struct {
+			Email string
+		}
+		e := &struct {
+			Name string
+			Embedded
+		}{
+			Name: "name",
+		}
+		q := Query(&gocql.Query{}, names).Strict().BindStructMap(e, map[string]interface{}{
+			"email": "email",
+		})
+		if err := q.Err(); err == nil || !strings.HasPrefix(err.Error(), `bind error: map keys ["email"] are shadowed by fields of`) {
+			t.Fatal("expected shadowed keys error got", err)
+		}
+
+		q = Query(&gocql.Query{}, names).BindStructMap(e, map[string]interface{}{
+			"email": "email",
+		})
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("bind struct map not strict", func(t *testing.T) {
+		q := Query(&gocql.Query{}, names).BindStructMap(v, map[string]interface{}{
+			"email":    "email",
+			"new_emai": "email",
+		})
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("bind struct map err", func(t *testing.T) {
+		q := Query(&gocql.Query{}, names)
+		if err := q.BindStructMapErr(v, map[string]interface{}{"email": "email"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("bind struct map err missing names", func(t *testing.T) {
+		q := Query(&gocql.Query{}, []string{"name", "email", "age", "first"})
+		err := q.BindStructMapErr(v, map[string]interface{}{"email": "email"})
+		if err == nil || err.Error() != `bind error: could not find names ["age" "first"] in *struct { Name string } and map` {
+			t.Fatal("expected missing names error got", err)
+		}
+		if q.Err() != err {
+			t.Fatal("expected query error", err, "got", q.Err())
+		}
+	})
+
+	t.Run("bind struct map err nil", func(t *testing.T) {
+		q := Query(&gocql.Query{}, []string{"name", "email"})
+		err := q.BindStructMapErr(nil, map[string]interface{}{"email": "email"})
+		if err == nil || err.Error() != `bind error: could not find names ["name"] in <nil> and map` {
+			t.Fatal("expected missing names error got", err)
+		}
+
+		q = Query(&gocql.Query{}, []string{"email"})
+		if err := q.BindStructMapErr(nil, map[string]interface{}{"email": "email"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("bind map error", func(t *testing.T) {
+		q := Query(&gocql.Query{}, names).Strict().BindMap(map[string]interface{}{
+			"name":     "name",
+			"email":    "email",
+			"new_emai": "email",
+		})
+		if err := q.Err(); err == nil || err.Error() != `bind error: unused map keys ["new_emai"]` {
+			t.Fatal("expected unused keys error got", err)
+		}
+	})
+}
+
+func TestBind(t *testing.T) {
+	names := []string{"name", "age", "first"}
+
+	t.Run("simple", func(t *testing.T) {
+		q := Query(&gocql.Query{}, names).Bind("name", 30, "first")
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("too few", func(t *testing.T) {
+		q := Query(&gocql.Query{}, names).Bind("name", 30)
+		if err := q.Err(); err == nil || err.Error() != "bind error: expected 3 bind values, got 2" {
+			t.Fatal("expected count error got", err)
+		}
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		q := Query(&gocql.Query{}, names).Bind("name", 30, "first", "last")
+		if err := q.Err(); err == nil || err.Error() != "bind error: expected 3 bind values, got 4" {
+			t.Fatal("expected count error got", err)
+		}
+	})
+
+	t.Run("no names", func(t *testing.T) {
+		q := Query(&gocql.Query{}, nil).Bind("name", 30)
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestPageSize(t *testing.T) {
+	pageSize := func(q *Queryx) int64 {
+		// gocql.Query does not expose a getter for page size
+		return reflect.ValueOf(q.Query).Elem().FieldByName("pageSize").Int()
+	}
+
+	table := []struct {
+		Name     string
+		N        int
+		Expected int64
+	}{
+		{"positive", 10, 10},
+		{"zero disables paging", 0, 0},
+		{"negative disables paging", -1, 0},
+	}
+
+	for _, test := range table {
+		q := Query(&gocql.Query{}, nil).PageSize(5).PageSize(test.N)
+		if n := pageSize(q); n != test.Expected {
+			t.Error(test.Name, "expected", test.Expected, "got", n)
+		}
+	}
+
+	t.Run("options", func(t *testing.T) {
+		q := Query(&gocql.Query{}, nil).PageSize(5).ApplyOptions(QueryOptions{PageSize: -1})
+		if n := pageSize(q); n != 0 {
+			t.Error("expected paging disabled got", n)
+		}
+	})
+}
+
+func TestScanCASRelease(t *testing.T) {
+	gq := &gocql.Query{}
+	q := Query(gq, []string{"id"}).Consistency(gocql.One).BindMap(qb.M{})
+
+	applied, err := q.ScanCASRelease()
+	if err == nil || err.Error() != `bind error: could not find name "id" in map[string]interface {}{}` {
+		t.Fatal("expected bind error got", err)
+	}
+	if applied {
+		t.Fatal("expected not applied")
+	}
+	if gq.GetConsistency() != 0 {
+		t.Fatal("expected query to be released")
+	}
+}
+
+func TestFieldFilter(t *testing.T) {
+	v := &struct {
+		Name     string
+		Password string `db:"password" gocqlx:"skip"`
+		Address  struct {
+			City string
+			Zip  string `gocqlx:"skip"`
+		}
+	}{
+		Name:     "name",
+		Password: "secret",
+	}
+	v.Address.City = "city"
+	v.Address.Zip = "zip"
+
+	skip := func(f reflect.StructField) bool {
+		return f.Tag.Get("gocqlx") != "skip"
+	}
+
+	t.Run("bind struct", func(t *testing.T) {
+		q := Query(&gocql.Query{}, []string{"name", "address.city"}).FieldFilter(skip).BindStruct(v)
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("bind struct excluded", func(t *testing.T) {
+		q := Query(&gocql.Query{}, []string{"name", "password"}).FieldFilter(skip).BindStruct(v)
+		if err := q.Err(); err == nil || !strings.HasPrefix(err.Error(), `bind error: could not find name "password"`) {
+			t.Fatal("expected bind error got", err)
+		}
+	})
+
+	t.Run("bind struct excluded nested", func(t *testing.T) {
+		q := Query(&gocql.Query{}, []string{"address.zip"}).FieldFilter(skip).BindStruct(v)
+		if err := q.Err(); err == nil || !strings.HasPrefix(err.Error(), `bind error: could not find name "address.zip"`) {
+			t.Fatal("expected bind error got", err)
+		}
+	})
+
+	t.Run("bind struct map", func(t *testing.T) {
+		names := []string{"name", "password"}
+		args, err := bindStructArgsFilter(names, v, map[string]interface{}{"password": "hash"}, DefaultMapper, skip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(args, []interface{}{"name", "hash"}); diff != "" {
+			t.Error("args mismatch", diff)
+		}
+	})
+
+	t.Run("bind struct map err", func(t *testing.T) {
+		q := Query(&gocql.Query{}, []string{"name", "password", "address.zip"}).FieldFilter(skip)
+		err := q.BindStructMapErr(v, map[string]interface{}{"password": "hash"})
+		if err == nil || !strings.HasPrefix(err.Error(), `bind error: could not find names ["address.zip"]`) {
+			t.Fatal("expected missing names error got", err)
+		}
+	})
+}
+
+func TestRelease(t *testing.T) {
+	q := Query(&gocql.Query{}, []string{"name"}).Strict().BindMap(qb.M{})
+	if q.Err() == nil {
+		t.Fatal("expected bind error")
+	}
+	q.tracer = &traceCollector{}
+	q.lastAttempts = 1
+	q.Release()
+	if q.Query != nil || q.Err() != ErrQueryReleased {
+		t.Fatal("expected released query to be reset got", q)
+	}
+	if q.tracer == nil || q.LastAttempts() != 1 {
+		t.Fatal("expected results of the execution to be kept got", q)
+	}
+
+	// second release is a no-op
+	q.Release()
+
+	// use after release reports an error
+	if err := q.BindMap(qb.M{"name": "name"}).Err(); err != ErrQueryReleased {
+		t.Fatal("expected ErrQueryReleased got", err)
+	}
+	if err := q.Exec(); err != ErrQueryReleased {
+		t.Fatal("expected ErrQueryReleased got", err)
+	}
+	var v string
+	if err := q.Get(&v); err != ErrQueryReleased {
+		t.Fatal("expected ErrQueryReleased got", err)
+	}
+	if err := q.SelectRelease(&[]string{}); err != ErrQueryReleased {
+		t.Fatal("expected ErrQueryReleased got", err)
+	}
+	if _, err := q.GetCAS(&v); err != ErrQueryReleased {
+		t.Fatal("expected ErrQueryReleased got", err)
+	}
+	if q.LastAttempts() != 1 {
+		t.Fatal("expected stats to be kept got", q.LastAttempts())
+	}
+
+	g := &gocql.Query{}
+	Query(g, nil).Bind("foo").Release()
+	// gocql.Query does not expose a getter for bound values, the released
+	// query is reused by gocql and must not keep them
+	if n := reflect.ValueOf(g).Elem().FieldByName("values").Len(); n != 0 {
+		t.Fatal("expected no bound values got", n)
+	}
+}
+
+func TestZeroTime(t *testing.T) {
+	var (
+		zero = time.Time{}
+		now  = time.Now()
+		v    = []interface{}{zero, &zero, now, &now, (*time.Time)(nil), "foo"}
+	)
+
+	table := []struct {
+		Name     string
+		Mode     zeroTimeMode
+		Expected []interface{}
+	}{
+		{
+			Name:     "default",
+			Mode:     zeroTimeValue,
+			Expected: []interface{}{zero, &zero, now, &now, (*time.Time)(nil), "foo"},
+		},
+		{
+			Name:     "null",
+			Mode:     zeroTimeNull,
+			Expected: []interface{}{nil, nil, now, &now, (*time.Time)(nil), "foo"},
+		},
+		{
+			Name:     "unset",
+			Mode:     zeroTimeUnset,
+			Expected: []interface{}{gocql.UnsetValue, gocql.UnsetValue, now, &now, (*time.Time)(nil), "foo"},
+		},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(test.Expected, zeroTimeValues(v, test.Mode)); diff != "" {
+			t.Error(test.Name, diff)
+		}
+	}
+	if v[0] != zero {
+		t.Fatal("expected values not to be modified got", v)
+	}
+}
+
+type rowMarshalerPerson struct {
+	Name string
+	Age  int
+}
+
+func (p *rowMarshalerPerson) MarshalRow(names []string) ([]interface{}, error) {
+	v := make([]interface{}, len(names))
+	for i, n := range names {
+		switch n {
+		case "name":
+			v[i] = strings.ToUpper(p.Name)
+		case "age":
+			v[i] = p.Age
+		default:
+			return nil, fmt.Errorf("unknown name %q", n)
+		}
+	}
+	return v, nil
+}
+
+func TestBindStructRowMarshaler(t *testing.T) {
+	p := &rowMarshalerPerson{Name: "foo", Age: 30}
+
+	args, err := bindStructArgs([]string{"age", "name"}, p, nil, DefaultMapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]interface{}{30, "FOO"}, args); diff != "" {
+		t.Fatal(diff)
+	}
+
+	q := Query(&gocql.Query{}, []string{"name", "email"}).BindStruct(p)
+	if err := q.Err(); err == nil || err.Error() != `bind error: unknown name "email"` {
+		t.Fatal("expected bind error got", err)
+	}
+	t.Run("overrides", func(t *testing.T) {
+		args, err := bindStructOverrideArgs([]string{"age", "email", "name"}, p, qb.M{"email": "foo@example.com", "age": qb.Unset}, DefaultMapper, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]interface{}{gocql.UnsetValue, "foo@example.com", "FOO"}, args); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+	t.Run("BindStructMap", func(t *testing.T) {
+		args, err := bindStructArgs([]string{"name", "email"}, p, qb.M{"email": "foo@example.com"}, DefaultMapper)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]interface{}{"FOO", "foo@example.com"}, args); diff != "" {
+			t.Fatal(diff)
+		}
+
+		q := Query(&gocql.Query{}, []string{"name", "email"}).Strict().BindStructMap(p, qb.M{"email": "foo@example.com"})
+		if err := q.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
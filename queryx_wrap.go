@@ -6,6 +6,7 @@ package gocqlx
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gocql/gocql"
 )
@@ -45,7 +46,13 @@ func (q *Queryx) Observer(observer gocql.QueryObserver) *Queryx {
 // This is useful for iterating over large result sets, but setting the
 // page size too low might decrease the performance. This feature is only
 // available in Cassandra 2 and onwards.
+//
+// If n <= 0 paging is disabled and the whole result is fetched in a single
+// page, use it with care for queries that may return many rows.
 func (q *Queryx) PageSize(n int) *Queryx {
+	if n < 0 {
+		n = 0
+	}
 	q.Query.PageSize(n)
 	return q
 }
@@ -118,9 +125,20 @@ func (q *Queryx) Idempotent(value bool) *Queryx {
 }
 
 // Bind sets query arguments of query. This can also be used to rebind new query arguments
-// to an existing query instance.
+// to an existing query instance. If query has names the number of arguments
+// must match the number of names. Zero time.Time values are bound according to
+// ZeroTimeAsNull and ZeroTimeAsUnset.
 func (q *Queryx) Bind(v ...interface{}) *Queryx {
-	q.Query.Bind(v...)
+	if q.Names != nil && len(v) != len(q.Names) {
+		q.err = fmt.Errorf("bind error: expected %d bind values, got %d", len(q.Names), len(v))
+		return q
+	}
+	if q.Query == nil {
+		q.err = ErrQueryReleased
+		return q
+	}
+	q.err = nil
+	q.Query.Bind(zeroTimeValues(v, q.zeroTime)...)
 	return q
 }
 
@@ -0,0 +1,59 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"reflect"
+
+	"github.com/gocql/gocql"
+)
+
+// RowUnmarshaler is implemented by structs that decode whole result rows
+// themselves, StructScan calls UnmarshalRow instead of mapping columns to
+// fields with reflection. This is an escape hatch for performance critical
+// rows or specialized encodings.
+//
+// UnmarshalRow is called with the result columns and copies of the raw column
+// values, a null value is nil. The values can be decoded with gocql.Unmarshal,
+// the values slice is reused between rows and must not be retained.
+type RowUnmarshaler interface {
+	UnmarshalRow(columns []gocql.ColumnInfo, values [][]byte) error
+}
+
+// RowMarshaler is implemented by structs that produce query parameters
+// themselves, BindStruct calls MarshalRow instead of mapping names to fields
+// with reflection. MarshalRow is called with the query parameter names and
+// must return a value for every name.
+type RowMarshaler interface {
+	MarshalRow(names []string) ([]interface{}, error)
+}
+
+var rowUnmarshalerInterface = reflect.TypeOf((*RowUnmarshaler)(nil)).Elem()
+
+// rowScan scans the current row into u.
+func (iter *Iterx) rowScan(u RowUnmarshaler) bool {
+	columns := iter.Columns()
+	if !iter.started {
+		iter.raw = make([]rawColumn, len(columns))
+		iter.rawValues = make([][]byte, len(columns))
+		iter.dests = make([]interface{}, len(columns))
+		for i := range iter.raw {
+			iter.dests[i] = &iter.raw[i]
+		}
+		iter.started = true
+	}
+
+	if !iter.Scan(iter.dests...) {
+		return false
+	}
+	for i := range iter.raw {
+		iter.rawValues[i] = iter.raw[i].data
+	}
+	if err := u.UnmarshalRow(columns, iter.rawValues); err != nil {
+		iter.err = err
+		return false
+	}
+	return true
+}
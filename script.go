@@ -0,0 +1,95 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitStatements splits a CQL script into statements separated by ';'.
+// Semicolons inside string literals, '$$' quoted strings i.e. function
+// bodies, quoted identifiers and comments ('--', '//' and '/* */') are
+// ignored. Comments are preserved, empty statements are skipped and
+// statements are trimmed.
+func SplitStatements(script string) []string {
+	var (
+		stmts []string
+		start int
+	)
+
+	add := func(stmt string) {
+		if !isEmptyStatement(stmt) {
+			stmts = append(stmts, strings.TrimSpace(stmt))
+		}
+	}
+
+	for i := 0; i < len(script); i++ {
+		switch c := script[i]; {
+		case c == '\'' || c == '"':
+			// skip to closing quote, doubled quote is an escape
+			for i++; i < len(script); i++ {
+				if script[i] == c {
+					if i+1 < len(script) && script[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case c == '$' && strings.HasPrefix(script[i:], "$$"):
+			// skip to closing $$, used for function bodies
+			if j := strings.Index(script[i+2:], "$$"); j >= 0 {
+				i += j + 3
+			} else {
+				i = len(script)
+			}
+		case c == '-' && strings.HasPrefix(script[i:], "--"),
+			c == '/' && strings.HasPrefix(script[i:], "//"):
+			if j := strings.IndexByte(script[i:], '\n'); j >= 0 {
+				i += j
+			} else {
+				i = len(script)
+			}
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			if j := strings.Index(script[i+2:], "*/"); j >= 0 {
+				i += j + 3
+			} else {
+				i = len(script)
+			}
+		case c == ';':
+			add(script[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(script) {
+		add(script[start:])
+	}
+
+	return stmts
+}
+
+// isEmptyStatement returns true if stmt contains only whitespace and comments.
+func isEmptyStatement(stmt string) bool {
+	for i := 0; i < len(stmt); i++ {
+		switch {
+		case strings.HasPrefix(stmt[i:], "--"), strings.HasPrefix(stmt[i:], "//"):
+			j := strings.IndexByte(stmt[i:], '\n')
+			if j < 0 {
+				return true
+			}
+			i += j
+		case strings.HasPrefix(stmt[i:], "/*"):
+			j := strings.Index(stmt[i+2:], "*/")
+			if j < 0 {
+				return true
+			}
+			i += j + 3
+		case !unicode.IsSpace(rune(stmt[i])):
+			return false
+		}
+	}
+	return true
+}
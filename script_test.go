@@ -0,0 +1,62 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitStatements(t *testing.T) {
+	table := []struct {
+		Name   string
+		Script string
+		S      []string
+	}{
+		{
+			Name:   "simple",
+			Script: "CREATE TABLE a (id int PRIMARY KEY); CREATE TABLE b (id int PRIMARY KEY);",
+			S:      []string{"CREATE TABLE a (id int PRIMARY KEY)", "CREATE TABLE b (id int PRIMARY KEY)"},
+		},
+		{
+			Name:   "missing last semicolon",
+			Script: "INSERT INTO a (id) VALUES (1);\nINSERT INTO a (id) VALUES (2)\n",
+			S:      []string{"INSERT INTO a (id) VALUES (1)", "INSERT INTO a (id) VALUES (2)"},
+		},
+		{
+			Name:   "semicolon in string",
+			Script: "INSERT INTO a (id, val) VALUES (1, 'a;b');INSERT INTO a (id, val) VALUES (2, 'it''s;');",
+			S:      []string{"INSERT INTO a (id, val) VALUES (1, 'a;b')", "INSERT INTO a (id, val) VALUES (2, 'it''s;')"},
+		},
+		{
+			Name:   "semicolon in quoted identifier",
+			Script: `SELECT "a;b" FROM a;`,
+			S:      []string{`SELECT "a;b" FROM a`},
+		},
+		{
+			Name:   "semicolon in function body",
+			Script: "CREATE FUNCTION f(a int) RETURNS NULL ON NULL INPUT RETURNS int LANGUAGE lua AS $$ local b = a; return b * 2 $$;\nSELECT f(id) FROM a;",
+			S:      []string{"CREATE FUNCTION f(a int) RETURNS NULL ON NULL INPUT RETURNS int LANGUAGE lua AS $$ local b = a; return b * 2 $$", "SELECT f(id) FROM a"},
+		},
+		{
+			Name:   "comments",
+			Script: "-- first; comment\nINSERT INTO a (id) VALUES (1); // second; comment\n/* third;\ncomment */ INSERT INTO a (id) VALUES (2);\n-- trailing comment",
+			S:      []string{"-- first; comment\nINSERT INTO a (id) VALUES (1)", "// second; comment\n/* third;\ncomment */ INSERT INTO a (id) VALUES (2)"},
+		},
+		{
+			Name:   "empty",
+			Script: " ;\n; /* multi\nline */;",
+		},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			if diff := cmp.Diff(test.S, SplitStatements(test.Script)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+	"github.com/scylladb/gocqlx/qb"
+)
+
+// Session wraps gocql.Session and provides a modified Query function that
+// returns Queryx instance.
+type Session struct {
+	*gocql.Session
+	Mapper *reflectx.Mapper
+
+	ctx        context.Context
+	logger     QueryLogger
+	named      *namedCache
+	ignoreCase bool
+	nullPolicy NullPolicy
+	filter     FieldFilter
+
+	largePartition LargePartitionHandler
+}
+
+// NewSession wraps existing gocql.Session.
+func NewSession(session *gocql.Session) Session {
+	return Session{
+		Session: session,
+		Mapper:  defaultMapper(),
+		named:   newNamedCache(),
+	}
+}
+
+// WrapSession should be called on CreateSession() gocql function to convert
+// the created session to gocqlx.Session.
+//
+// Example:
+//     session, err := gocqlx.WrapSession(cluster.CreateSession())
+func WrapSession(session *gocql.Session, err error) (Session, error) {
+	return NewSession(session), err
+}
+
+// Query creates a new Queryx using the session mapper.
+// The stmt and names parameters are typically result of a query builder
+// (package qb) ToCql() function or come from table model (package table).
+// The names parameter is a list of query parameters' names and it's used for
+// binding.
+func (s Session) Query(stmt string, names []string) *Queryx {
+	q := s.Session.Query(stmt)
+	if s.ctx != nil {
+		q = q.WithContext(s.ctx)
+	}
+	if s.logger != nil {
+		q = q.Observer(queryLogger{log: s.logger, names: names})
+	}
+	return &Queryx{
+		Query:      q,
+		Names:      names,
+		Mapper:     s.Mapper,
+		session:    s.Session,
+		ignoreCase: s.ignoreCase,
+		nullPolicy: s.nullPolicy,
+		filter:     s.filter,
+
+		largePartition: s.largePartition,
+	}
+}
+
+// PreparedNamed returns a new Queryx for a named query i.e.
+// `SELECT * FROM t WHERE id=:id`, the query is compiled with
+// CompileNamedQuery once and cached under key, subsequent calls with the same
+// key and query reuse the compiled statement and bind names. Prepared
+// statement is cached by gocql for the compiled statement. PreparedNamed is
+// meant for hot paths that execute the same named query over and over.
+func (s Session) PreparedNamed(key, query string) (*Queryx, error) {
+	q, err := s.named.get(key, query)
+	if err != nil {
+		return nil, err
+	}
+	// names are copied so that changes to the Queryx do not corrupt the cache
+	return s.Query(q.stmt, append([]string(nil), q.names...)), nil
+}
+
+// WithContext returns a copy of the session bound to ctx, all the queries
+// created by the returned session, including the ones created by helpers like
+// ExecStmt or ExecScript, are executed with ctx.
+func (s Session) WithContext(ctx context.Context) Session {
+	s.ctx = ctx
+	return s
+}
+
+// IgnoreCase returns a copy of the session that scans results of its queries
+// matching column names to fields case-insensitively, see Iterx.IgnoreCase.
+func (s Session) IgnoreCase() Session {
+	s.ignoreCase = true
+	return s
+}
+
+// NullPolicy returns a copy of the session that scans results of its queries
+// with the NULL handling policy p, see Iterx.NullPolicy.
+func (s Session) NullPolicy(p NullPolicy) Session {
+	s.nullPolicy = p
+	return s
+}
+
+// FieldFilter returns a copy of the session with queries using filter to
+// decide which struct fields are bound and scanned, see Queryx.FieldFilter.
+func (s Session) FieldFilter(filter FieldFilter) Session {
+	s.filter = filter
+	return s
+}
+
+// QueryStatement creates a new Queryx from qb.Statement using the session
+// mapper, it's equivalent to calling Query(stmt.Stmt, stmt.Names).
+func (s Session) QueryStatement(stmt qb.Statement) *Queryx {
+	return s.Query(stmt.Stmt, stmt.Names)
+}
+
+// ExecStmt creates query and executes the given statement. It's executed
+// with the session context, if any, see WithContext.
+func (s Session) ExecStmt(stmt string) error {
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return s.ExecStmtContext(ctx, stmt)
+}
+
+// ExecStmtContext is like ExecStmt but the statement is executed with ctx,
+// this allows to time out schema changes i.e. in migrations.
+func (s Session) ExecStmtContext(ctx context.Context, stmt string) error {
+	return s.Query(stmt, nil).WithContext(ctx).ExecRelease()
+}
+
+// AwaitSchemaAgreement blocks until all the nodes in the cluster agree on the
+// schema version or ctx is done. It should be called after schema changes
+// i.e. CREATE TABLE or CREATE TYPE before the new schema is used.
+func (s Session) AwaitSchemaAgreement(ctx context.Context) error {
+	return s.Session.AwaitSchemaAgreement(ctx)
+}
+
+// Truncate removes all the data from table. If awaitAgreement is true it
+// waits for schema agreement first so that a table created just before is
+// known to all the nodes, ctx bounds both the wait and the TRUNCATE
+// statement. It's meant for cleaning up tables between test cases.
+func (s Session) Truncate(ctx context.Context, table string, awaitAgreement bool) error {
+	if awaitAgreement {
+		if err := s.AwaitSchemaAgreement(ctx); err != nil {
+			return fmt.Errorf("awaiting schema agreement failed: %s", err)
+		}
+	}
+	return s.ExecStmtContext(ctx, "TRUNCATE TABLE "+table)
+}
+
+// ExecScript splits script into statements (see SplitStatements) and executes
+// them in order. It stops on the first error and reports which statement
+// failed.
+func (s Session) ExecScript(script string) error {
+	for i, stmt := range SplitStatements(script) {
+		if err := s.ExecStmt(stmt); err != nil {
+			return fmt.Errorf("statement %d failed: %s", i+1, err)
+		}
+	}
+	return nil
+}
+
+// CreateIfNotExists executes builder as INSERT ... IF NOT EXISTS with values
+// bound from arg, builder is not modified. It returns true if the row was
+// created, otherwise the existing row is scanned into dest, which must be
+// a struct pointer. Builder errors, see qb.InsertBuilder.Err, are returned
+// before executing the query.
+func (s Session) CreateIfNotExists(builder *qb.InsertBuilder, arg, dest interface{}) (created bool, err error) {
+	if err := builder.Err(); err != nil {
+		return false, err
+	}
+	b := *builder
+	return s.Query(b.Unique().ToCql()).BindStruct(arg).GetCASRelease(dest)
+}
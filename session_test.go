@@ -0,0 +1,666 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package gocqlx_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx"
+	. "github.com/scylladb/gocqlx/gocqlxtest"
+	"github.com/scylladb/gocqlx/qb"
+	"github.com/scylladb/gocqlx/table"
+)
+
+func TestSessionQueryStatement(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.session_statement_table (id int PRIMARY KEY, val text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type Row struct {
+		ID  int
+		Val string
+	}
+	m := Row{ID: 1, Val: "foo"}
+
+	insert := qb.Build(qb.Insert("gocqlx_test.session_statement_table").Columns("id", "val"))
+	if err := session.QueryStatement(insert).BindStruct(m).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	get := qb.NewStatement(qb.Select("gocqlx_test.session_statement_table").Where(qb.Eq("id")).ToCql())
+	var v Row
+	if err := session.QueryStatement(get).BindStruct(m).GetRelease(&v); err != nil {
+		t.Fatal("get:", err)
+	}
+	if !reflect.DeepEqual(m, v) {
+		t.Fatal("not equals")
+	}
+}
+
+func TestSessionPreparedNamed(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	const query = `SELECT * FROM system.local WHERE key=:key`
+
+	q, err := session.PreparedNamed("local", query)
+	if err != nil {
+		t.Fatal("prepared named:", err)
+	}
+	q.Names[0] = "foo"
+	q.Names = append(q.Names, "bar")
+	q.Release()
+
+	q, err = session.PreparedNamed("local", query)
+	if err != nil {
+		t.Fatal("prepared named:", err)
+	}
+	defer q.Release()
+	if diff := cmp.Diff([]string{"key"}, q.Names); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestSessionAwaitSchemaAgreement(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.session_schema_agreement_table (id int PRIMARY KEY)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.AwaitSchemaAgreement(context.Background()); err != nil {
+		t.Fatal("await schema agreement:", err)
+	}
+
+	var v []int
+	if err := session.Query(`SELECT id FROM gocqlx_test.session_schema_agreement_table`, nil).SelectRelease(&v); err != nil {
+		t.Fatal("select:", err)
+	}
+	if len(v) != 0 {
+		t.Fatal("expected no rows got", len(v))
+	}
+}
+
+func TestSessionExecScript(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	const script = `
+CREATE TABLE gocqlx_test.session_script_table (id int PRIMARY KEY, val text);
+-- values with semicolons
+INSERT INTO gocqlx_test.session_script_table (id, val) VALUES (1, 'a;b');
+INSERT INTO gocqlx_test.session_script_table (id, val) VALUES (2, 'c');
+`
+	if err := session.ExecScript(script); err != nil {
+		t.Fatal("exec script:", err)
+	}
+
+	var v []string
+	if err := session.Query(`SELECT val FROM gocqlx_test.session_script_table WHERE id IN (1, 2)`, nil).SelectRelease(&v); err != nil {
+		t.Fatal("select:", err)
+	}
+	if diff := cmp.Diff([]string{"a;b", "c"}, v); diff != "" {
+		t.Fatal(diff)
+	}
+
+	err := session.ExecScript(`INSERT INTO gocqlx_test.session_script_table (id, val) VALUES (3, 'd'); WRONG;`)
+	if err == nil || !strings.HasPrefix(err.Error(), "statement 2 failed") {
+		t.Fatal("expected statement 2 error got", err)
+	}
+}
+
+func TestSessionCreateIfNotExists(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.session_create_table (id int PRIMARY KEY, val text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type Item struct {
+		ID  int
+		Val string
+	}
+
+	b := qb.Insert("gocqlx_test.session_create_table").Columns("id", "val")
+
+	var existing Item
+	created, err := session.CreateIfNotExists(b, &Item{ID: 1, Val: "first"}, &existing)
+	if err != nil {
+		t.Fatal("create:", err)
+	}
+	if !created {
+		t.Fatal("expected created")
+	}
+	if diff := cmp.Diff(Item{}, existing); diff != "" {
+		t.Fatal(diff)
+	}
+
+	created, err = session.CreateIfNotExists(b, &Item{ID: 1, Val: "second"}, &existing)
+	if err != nil {
+		t.Fatal("create:", err)
+	}
+	if created {
+		t.Fatal("expected not created")
+	}
+	if diff := cmp.Diff(Item{ID: 1, Val: "first"}, existing); diff != "" {
+		t.Fatal(diff)
+	}
+	if stmt, _ := b.ToCql(); strings.Contains(stmt, "IF NOT EXISTS") {
+		t.Fatal("expected builder not modified got", stmt)
+	}
+
+	bad := qb.Insert("gocqlx_test.session_create_table").Columns("id", "val").Using("TTL 1").TTL(time.Second)
+	if _, err := session.CreateIfNotExists(bad, &Item{ID: 2}, &existing); err == nil {
+		t.Fatal("expected builder error")
+	}
+}
+
+func TestSessionWithContext(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.session_ctx_table (id int PRIMARY KEY)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := session.WithContext(ctx)
+
+	if err := s.ExecStmt(`INSERT INTO gocqlx_test.session_ctx_table (id) VALUES (1)`); err != context.Canceled {
+		t.Fatal("expected context canceled got", err)
+	}
+	if err := s.ExecScript(`INSERT INTO gocqlx_test.session_ctx_table (id) VALUES (1);`); err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Fatal("expected context canceled got", err)
+	}
+	var v []int
+	if err := s.Query(`SELECT id FROM gocqlx_test.session_ctx_table`, nil).SelectRelease(&v); err != context.Canceled {
+		t.Fatal("expected context canceled got", err)
+	}
+
+	// the original session is not bound to the context
+	if err := session.ExecStmt(`INSERT INTO gocqlx_test.session_ctx_table (id) VALUES (1)`); err != nil {
+		t.Fatal("insert:", err)
+	}
+}
+
+func TestSessionExecStmtContext(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmtContext(context.Background(), `CREATE TABLE gocqlx_test.exec_stmt_ctx_table (id int PRIMARY KEY)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := session.ExecStmtContext(ctx, `INSERT INTO gocqlx_test.exec_stmt_ctx_table (id) VALUES (1)`); err != context.Canceled {
+		t.Fatal("expected context canceled got", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	if err := session.ExecStmtContext(ctx, `DROP TABLE gocqlx_test.exec_stmt_ctx_table`); err != context.DeadlineExceeded {
+		t.Fatal("expected deadline exceeded got", err)
+	}
+}
+
+func TestSessionTruncate(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.truncate_table (id int PRIMARY KEY)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := session.Query(`INSERT INTO gocqlx_test.truncate_table (id) VALUES (?)`, nil).Bind(i).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	truncate := func(t *testing.T, awaitAgreement bool) {
+		t.Helper()
+
+		if err := session.Truncate(ctx, "gocqlx_test.truncate_table", awaitAgreement); err != nil {
+			t.Fatal("truncate:", err)
+		}
+
+		var v []int
+		if err := session.Query(`SELECT id FROM gocqlx_test.truncate_table`, nil).SelectRelease(&v); err != nil {
+			t.Fatal("select:", err)
+		}
+		if len(v) != 0 {
+			t.Fatal("expected empty table got", v)
+		}
+	}
+
+	t.Run("await agreement", func(t *testing.T) {
+		truncate(t, true)
+	})
+
+	t.Run("no agreement", func(t *testing.T) {
+		if err := session.Query(`INSERT INTO gocqlx_test.truncate_table (id) VALUES (?)`, nil).Bind(1).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+		truncate(t, false)
+	})
+}
+
+func TestSessionSelectMany(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.select_many_user_table (user_id int PRIMARY KEY, name text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.select_many_order_table (user_id int, order_id int, PRIMARY KEY (user_id, order_id))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.ExecStmt(`INSERT INTO gocqlx_test.select_many_user_table (user_id, name) VALUES (1, 'name')`); err != nil {
+		t.Fatal("insert:", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := session.Query(`INSERT INTO gocqlx_test.select_many_order_table (user_id, order_id) VALUES (1, ?)`, nil).Bind(i).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	type User struct {
+		UserID int
+		Name   string
+	}
+	type Order struct {
+		UserID  int
+		OrderID int
+	}
+	arg := struct{ UserID int }{1}
+
+	for _, test := range []struct {
+		Name string
+		Fn   func(arg interface{}, reads ...gocqlx.Read) error
+	}{
+		{"sequential", session.SelectMany},
+		{"concurrent", session.SelectManyConcurrent},
+	} {
+		test := test
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				users  []User
+				orders []Order
+			)
+			err := test.Fn(arg,
+				gocqlx.Read{Builder: qb.Select("gocqlx_test.select_many_user_table").Where(qb.Eq("user_id")), Dest: &users},
+				gocqlx.Read{Builder: qb.Select("gocqlx_test.select_many_order_table").Where(qb.Eq("user_id")), Dest: &orders},
+			)
+			if err != nil {
+				t.Fatal("select many:", err)
+			}
+			if diff := cmp.Diff([]User{{1, "name"}}, users); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff([]Order{{1, 0}, {1, 1}}, orders); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		var users []User
+		err := session.SelectMany(arg,
+			gocqlx.Read{Builder: qb.Select("gocqlx_test.select_many_user_table").Where(qb.Eq("user_id")), Dest: &users},
+			gocqlx.Read{Builder: qb.Select("gocqlx_test.select_many_nonexistent_table").Where(qb.Eq("user_id")), Dest: &users},
+		)
+		if err == nil || !strings.HasPrefix(err.Error(), "read 2 failed: ") {
+			t.Fatal("expected read 2 error got", err)
+		}
+	})
+}
+
+func TestSessionExecBatch(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.exec_batch_table (id int PRIMARY KEY, name text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	insert := qb.Insert("gocqlx_test.exec_batch_table").Columns("id", "name")
+	stmt, names := qb.Batch().
+		AddWithPrefix("a", insert).
+		AddWithPrefix("b", insert).
+		ToCql()
+
+	arg := struct {
+		AID   int    `db:"a.id"`
+		AName string `db:"a.name"`
+		BID   int    `db:"b.id"`
+		BName string `db:"b.name"`
+	}{1, "a", 2, "b"}
+
+	if err := session.ExecBatch(stmt, names, arg); err != nil {
+		t.Fatal("exec batch:", err)
+	}
+
+	var v []string
+	if err := session.Query(`SELECT name FROM gocqlx_test.exec_batch_table`, nil).SelectRelease(&v); err != nil {
+		t.Fatal("select:", err)
+	}
+	sort.Strings(v)
+	if diff := cmp.Diff([]string{"a", "b"}, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestSessionNewBatch(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.new_batch_user_table (id int PRIMARY KEY, name text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.new_batch_event_table (user_id int, seq int, kind text, PRIMARY KEY (user_id, seq))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type User struct {
+		ID   int
+		Name string
+	}
+	type Event struct {
+		UserID int
+		Seq    int
+		Kind   string
+	}
+
+	user := User{ID: 1, Name: "foo"}
+	events := []Event{{1, 1, "created"}, {1, 2, "renamed"}}
+
+	b := session.NewBatch(gocql.LoggedBatch).
+		Add(qb.Insert("gocqlx_test.new_batch_user_table").Columns("id", "name"), user)
+	for _, e := range events {
+		b.Add(qb.Insert("gocqlx_test.new_batch_event_table").Columns("user_id", "seq", "kind"), e)
+	}
+	b.Add(qb.Update("gocqlx_test.new_batch_user_table").Set("name").Where(qb.Eq("id")), qb.M{"id": 1, "name": "bar"})
+
+	if err := b.Exec(); err != nil {
+		t.Fatal("exec batch:", err)
+	}
+
+	var u User
+	if err := session.Query(`SELECT * FROM gocqlx_test.new_batch_user_table WHERE id = 1`, nil).GetRelease(&u); err != nil {
+		t.Fatal("get:", err)
+	}
+	if diff := cmp.Diff(User{ID: 1, Name: "bar"}, u); diff != "" {
+		t.Fatal(diff)
+	}
+
+	var v []Event
+	if err := session.Query(`SELECT * FROM gocqlx_test.new_batch_event_table WHERE user_id = 1`, nil).SelectRelease(&v); err != nil {
+		t.Fatal("select:", err)
+	}
+	if diff := cmp.Diff(events, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestSessionNewBatchExecCAS(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.new_batch_cas_table (k int, c int, v text, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type Row struct {
+		Applied bool
+		K       int
+		C       int
+		V       string
+	}
+
+	insert := qb.Insert("gocqlx_test.new_batch_cas_table").Columns("k", "c", "v").Unique()
+	newBatch := func(v string) *gocqlx.Batch {
+		b := session.NewBatch(gocql.LoggedBatch).
+			Add(insert, Row{K: 1, C: 1, V: v}).
+			Add(insert, Row{K: 1, C: 2, V: v})
+		b.SerialConsistency(gocql.LocalSerial)
+		return b
+	}
+
+	var rows []Row
+	applied, err := newBatch("a").ExecCAS(&rows)
+	if err != nil {
+		t.Fatal("exec cas:", err)
+	}
+	if !applied {
+		t.Fatal("expected applied")
+	}
+	for _, r := range rows {
+		if !r.Applied {
+			t.Fatal("expected all rows applied got", rows)
+		}
+	}
+
+	rows = nil
+	applied, err = newBatch("b").ExecCAS(&rows)
+	if err != nil {
+		t.Fatal("exec cas:", err)
+	}
+	if applied {
+		t.Fatal("expected not applied")
+	}
+	golden := []Row{{false, 1, 1, "a"}, {false, 1, 2, "a"}}
+	if diff := cmp.Diff(golden, rows); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestTableMetadata(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.table_metadata_table (a int, b text, c int, s text static, v list<text>, PRIMARY KEY ((a, b), c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.AwaitSchemaAgreement(context.Background()); err != nil {
+		t.Fatal("await schema agreement:", err)
+	}
+
+	s, err := gocqlx.TableMetadata(session.Session, "gocqlx_test", "table_metadata_table")
+	if err != nil {
+		t.Fatal("table metadata:", err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, s.Metadata.PartKey); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"c"}, s.Metadata.SortKey); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"s"}, s.Metadata.Static); diff != "" {
+		t.Error(diff)
+	}
+	if len(s.Metadata.Columns) != 5 {
+		t.Error("expected 5 columns got", s.Metadata.Columns)
+	}
+	if typ := s.Types["v"].Type(); typ != gocql.TypeList {
+		t.Error("expected list type got", typ)
+	}
+
+	// metadata can be used to build queries
+	tbl := table.New(s.Metadata)
+	if err := session.Query(tbl.Insert()).BindMap(qb.M{"a": 1, "b": "b", "c": 1, "s": "s", "v": []string{"v"}}).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	if _, err := gocqlx.TableMetadata(session.Session, "gocqlx_test", "table_metadata_nonexistent_table"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSessionSelectOr(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.select_or_table (k int, c int, v text, PRIMARY KEY (k, c))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for c := 0; c < 5; c++ {
+		if err := session.Query(`INSERT INTO gocqlx_test.select_or_table (k, c, v) VALUES (1, ?, ?)`, nil).Bind(c, fmt.Sprint(c)).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	type Row struct {
+		K int
+		C int
+		V string
+	}
+
+	builder := qb.Select("gocqlx_test.select_or_table").Where(qb.Eq("k"), qb.In("c"))
+	args := []interface{}{
+		qb.M{"k": 1, "c": []int{1, 2}},
+		struct {
+			K int
+			C []int
+		}{1, []int{2, 3}},
+	}
+
+	t.Run("dedup", func(t *testing.T) {
+		var v []Row
+		if err := session.SelectOr(builder, args, &v, "k", "c"); err != nil {
+			t.Fatal("select or:", err)
+		}
+		expected := []Row{{1, 1, "1"}, {1, 2, "2"}, {1, 3, "3"}}
+		if diff := cmp.Diff(expected, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		var v []*Row
+		if err := session.SelectOr(builder, args, &v); err != nil {
+			t.Fatal("select or:", err)
+		}
+		if len(v) != 4 {
+			t.Fatal("expected 4 rows got", len(v))
+		}
+	})
+}
+
+func TestQueryxScanCASRelease(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.scan_cas_table (id int PRIMARY KEY, val text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	stmt, names := qb.Insert("gocqlx_test.scan_cas_table").Columns("id", "val").Unique().ToCql()
+
+	var (
+		id  int
+		val string
+	)
+	applied, err := session.Query(stmt, names).BindMap(qb.M{"id": 1, "val": "first"}).ScanCASRelease(&id, &val)
+	if err != nil {
+		t.Fatal("insert:", err)
+	}
+	if !applied {
+		t.Fatal("expected applied")
+	}
+
+	applied, err = session.Query(stmt, names).BindMap(qb.M{"id": 1, "val": "second"}).ScanCASRelease(&id, &val)
+	if err != nil {
+		t.Fatal("insert:", err)
+	}
+	if applied {
+		t.Fatal("expected not applied")
+	}
+	if id != 1 || val != "first" {
+		t.Fatal("unexpected conflict row", id, val)
+	}
+}
+
+func TestQueryxTraced(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.traced_table (id int PRIMARY KEY)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	gocqlx.DefaultStructOnly = true
+	defer func() { gocqlx.DefaultStructOnly = false }()
+
+	q := session.Query(`INSERT INTO gocqlx_test.traced_table (id) VALUES (1)`, nil).Traced()
+	if err := q.ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := q.TraceEventsContext(ctx); err == nil {
+		t.Fatal("expected context error")
+	}
+
+	events, err := q.TraceEvents()
+	if err != nil {
+		t.Fatal("trace events:", err)
+	}
+	if len(events) == 0 {
+		t.Fatal("expected trace events")
+	}
+	if events[0].Activity == "" {
+		t.Fatal("expected activity got", events[0])
+	}
+}
+
+func TestSessionWithQueryLogger(t *testing.T) {
+	session := gocqlx.NewSession(CreateSession(t))
+	defer session.Close()
+
+	var (
+		stmts []string
+		names [][]string
+	)
+	s := session.WithQueryLogger(func(ctx context.Context, stmt string, n []string, err error, d time.Duration) {
+		if err != nil {
+			t.Error("unexpected error", err)
+		}
+		stmts = append(stmts, stmt)
+		names = append(names, n)
+	})
+
+	stmt, n := qb.Select("system.local").Columns("key").Where(qb.Eq("key")).ToCql()
+	var key string
+	if err := s.Query(stmt, n).BindMap(qb.M{"key": "local"}).GetRelease(&key); err != nil {
+		t.Fatal("get:", err)
+	}
+
+	if diff := cmp.Diff([]string{stmt}, stmts); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([][]string{{"key"}}, names); diff != "" {
+		t.Fatal(diff)
+	}
+}
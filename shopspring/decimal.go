@@ -0,0 +1,64 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// Package shopspring provides an adapter that allows to bind and scan
+// github.com/shopspring/decimal values. It's a separate package so that the
+// dependency is only compiled in by the users of the adapter.
+package shopspring
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/gocql/gocql"
+	"github.com/shopspring/decimal"
+	"gopkg.in/inf.v0"
+)
+
+// Decimal wraps decimal.Decimal and implements gocql.Marshaler and
+// gocql.Unmarshaler so that it can be used with decimal and varint columns
+// instead of inf.Dec and big.Int.
+type Decimal struct {
+	decimal.Decimal
+}
+
+// NewDecimal returns Decimal wrapping d.
+func NewDecimal(d decimal.Decimal) Decimal {
+	return Decimal{d}
+}
+
+// MarshalCQL implements gocql.Marshaler.
+func (d Decimal) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if info.Type() == gocql.TypeVarint {
+		if d.Exponent() < 0 && !d.Equal(d.Truncate(0)) {
+			return nil, fmt.Errorf("can not marshal %s into varint", d)
+		}
+		return gocql.Marshal(info, d.BigInt())
+	}
+	return gocql.Marshal(info, inf.NewDecBig(d.Coefficient(), inf.Scale(-d.Exponent())))
+}
+
+// UnmarshalCQL implements gocql.Unmarshaler.
+func (d *Decimal) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if data == nil {
+		d.Decimal = decimal.Decimal{}
+		return nil
+	}
+
+	if info.Type() == gocql.TypeVarint {
+		var v big.Int
+		if err := gocql.Unmarshal(info, data, &v); err != nil {
+			return err
+		}
+		d.Decimal = decimal.NewFromBigInt(&v, 0)
+		return nil
+	}
+
+	var v inf.Dec
+	if err := gocql.Unmarshal(info, data, &v); err != nil {
+		return err
+	}
+	d.Decimal = decimal.NewFromBigInt(v.UnscaledBig(), -int32(v.Scale()))
+	return nil
+}
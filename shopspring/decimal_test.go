@@ -0,0 +1,64 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package shopspring
+
+import (
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/shopspring/decimal"
+)
+
+func TestDecimal(t *testing.T) {
+	decimalInfo := gocql.NewNativeType(4, gocql.TypeDecimal, "")
+	varintInfo := gocql.NewNativeType(4, gocql.TypeVarint, "")
+
+	table := []struct {
+		Name string
+		Info gocql.TypeInfo
+		V    string
+	}{
+		{"decimal", decimalInfo, "123.456"},
+		{"negative decimal", decimalInfo, "-0.000001"},
+		{"large decimal", decimalInfo, "123456789012345678901234567890.123456789"},
+		{"decimal positive exponent", decimalInfo, "1.2e10"},
+		{"varint", varintInfo, "123456789012345678901234567890"},
+		{"negative varint", varintInfo, "-42"},
+		{"varint positive exponent", varintInfo, "12e3"},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			d := NewDecimal(decimal.RequireFromString(test.V))
+			b, err := gocql.Marshal(test.Info, d)
+			if err != nil {
+				t.Fatal("Marshal() error", err)
+			}
+			var v Decimal
+			if err := gocql.Unmarshal(test.Info, b, &v); err != nil {
+				t.Fatal("Unmarshal() error", err)
+			}
+			if !v.Equal(d.Decimal) {
+				t.Fatalf("Unmarshal()=%s expected %s", v, d)
+			}
+		})
+	}
+
+	t.Run("varint fraction", func(t *testing.T) {
+		if _, err := gocql.Marshal(varintInfo, NewDecimal(decimal.RequireFromString("1.5"))); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		v := NewDecimal(decimal.New(1, 0))
+		if err := gocql.Unmarshal(decimalInfo, nil, &v); err != nil {
+			t.Fatal("Unmarshal() error", err)
+		}
+		if !v.IsZero() {
+			t.Fatal("expected zero got", v)
+		}
+	})
+}
@@ -12,6 +12,9 @@ type Metadata struct {
 	Columns []string
 	PartKey []string
 	SortKey []string
+	// Static lists static columns, updates that only touch static columns
+	// are restricted by partition key only.
+	Static []string
 }
 
 type cql struct {
@@ -25,6 +28,7 @@ type Table struct {
 	metadata      Metadata
 	primaryKeyCmp []qb.Cmp
 	partKeyCmp    []qb.Cmp
+	static        map[string]struct{}
 
 	get    cql
 	sel    cql
@@ -48,6 +52,12 @@ func New(m Metadata) *Table { // nolint: gocritic
 	t.partKeyCmp = make([]qb.Cmp, len(m.PartKey))
 	copy(t.partKeyCmp, t.primaryKeyCmp[:len(t.metadata.PartKey)])
 
+	// prepare static columns
+	t.static = make(map[string]struct{}, len(m.Static))
+	for _, c := range m.Static {
+		t.static[c] = struct{}{}
+	}
+
 	// prepare get stmt
 	t.get.stmt, t.get.names = qb.Select(m.Name).Where(t.primaryKeyCmp...).ToCql()
 	// prepare select stmt
@@ -115,11 +125,28 @@ func (t *Table) Update(columns ...string) (stmt string, names []string) {
 	return t.UpdateBuilder(columns...).ToCql()
 }
 
-// UpdateBuilder returns a builder initialised to update by primary key statement.
+// UpdateBuilder returns a builder initialised to update by primary key
+// statement. If all the columns are static the update is by partition key.
 func (t *Table) UpdateBuilder(columns ...string) *qb.UpdateBuilder {
+	if t.isStatic(columns) {
+		return qb.Update(t.metadata.Name).Set(columns...).Where(t.partKeyCmp...)
+	}
 	return qb.Update(t.metadata.Name).Set(columns...).Where(t.primaryKeyCmp...)
 }
 
+// isStatic returns true if columns are not empty and all of them are static.
+func (t *Table) isStatic(columns []string) bool {
+	if len(columns) == 0 {
+		return false
+	}
+	for _, c := range columns {
+		if _, ok := t.static[c]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // Delete returns delete by primary key statement.
 func (t *Table) Delete(columns ...string) (stmt string, names []string) {
 	return t.DeleteBuilder(columns...).ToCql()
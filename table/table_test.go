@@ -160,6 +160,30 @@ func TestTableUpdate(t *testing.T) {
 			N: []string{"d", "a", "b"},
 			S: "UPDATE table SET d=? WHERE a=? AND b=? ",
 		},
+		{
+			M: Metadata{
+				Name:    "table",
+				Columns: []string{"a", "b", "c", "d"},
+				PartKey: []string{"a"},
+				SortKey: []string{"b"},
+				Static:  []string{"c", "d"},
+			},
+			C: []string{"c", "d"},
+			N: []string{"c", "d", "a"},
+			S: "UPDATE table SET c=?,d=? WHERE a=? ",
+		},
+		{
+			M: Metadata{
+				Name:    "table",
+				Columns: []string{"a", "b", "c", "d"},
+				PartKey: []string{"a"},
+				SortKey: []string{"b"},
+				Static:  []string{"d"},
+			},
+			C: []string{"c", "d"},
+			N: []string{"c", "d", "a", "b"},
+			S: "UPDATE table SET c=?,d=? WHERE a=? AND b=? ",
+		},
 	}
 
 	for _, test := range table {
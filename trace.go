@@ -0,0 +1,120 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// TraceEvent is an event recorded by a coordinator or a replica while
+// executing a traced query, see system_traces.events.
+type TraceEvent struct {
+	SessionID     gocql.UUID `db:"session_id"`
+	EventID       gocql.UUID `db:"event_id"`
+	Activity      string     `db:"activity"`
+	Source        net.IP     `db:"source"`
+	SourceElapsed int        `db:"source_elapsed"`
+	Thread        string     `db:"thread"`
+}
+
+// traceCollector is a gocql.Tracer that records trace session IDs.
+type traceCollector struct {
+	mu  sync.Mutex
+	ids []gocql.UUID
+}
+
+func (t *traceCollector) Trace(traceID []byte) {
+	id, err := gocql.UUIDFromBytes(traceID)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	t.ids = append(t.ids, id)
+	t.mu.Unlock()
+}
+
+func (t *traceCollector) sessionIDs() []gocql.UUID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]gocql.UUID(nil), t.ids...)
+}
+
+// Traced enables tracing of the query, after the query is executed the
+// recorded events can be fetched with TraceEvents. Traced replaces any tracer
+// set with Trace.
+func (q *Queryx) Traced() *Queryx {
+	q.tracer = &traceCollector{}
+	q.Query.Trace(q.tracer)
+	return q
+}
+
+// traceWait specifies how long TraceEvents waits for trace sessions to
+// complete, tracing data is written asynchronously.
+var traceWait = 2 * time.Second
+
+// TraceEvents returns events recorded for all the executions of a query with
+// tracing enabled by Traced. It requires the query to be created with
+// Session.Query.
+func (q *Queryx) TraceEvents() ([]TraceEvent, error) {
+	return q.TraceEventsContext(context.Background())
+}
+
+// TraceEventsContext is like TraceEvents but ctx bounds waiting for the trace
+// sessions to complete and fetching the events.
+func (q *Queryx) TraceEventsContext(ctx context.Context) ([]TraceEvent, error) {
+	if q.tracer == nil {
+		return nil, errors.New("tracing not enabled, call Traced")
+	}
+	if q.session == nil {
+		return nil, errors.New("tracing requires a query created with Session.Query")
+	}
+
+	var events []TraceEvent
+	for _, id := range q.tracer.sessionIDs() {
+		if err := q.awaitTrace(ctx, id); err != nil {
+			return nil, err
+		}
+		gq := q.session.Query(`SELECT session_id, event_id, activity, source, source_elapsed, thread FROM system_traces.events WHERE session_id = ?`, id).
+			WithContext(ctx)
+		// iterator options are fixed so that DefaultUnsafe and
+		// DefaultStructOnly do not apply
+		iter := &Iterx{Iter: gq.Iter(), Mapper: q.Mapper, query: gq}
+		if err := iter.SelectAppend(&events); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// awaitTrace waits until trace session is complete i.e. has a duration.
+func (q *Queryx) awaitTrace(ctx context.Context, id gocql.UUID) error {
+	deadline := time.Now().Add(traceWait)
+	for {
+		var duration *int
+		err := q.session.Query(`SELECT duration FROM system_traces.sessions WHERE session_id = ?`, id).
+			WithContext(ctx).
+			Scan(&duration)
+		if err != nil && err != gocql.ErrNotFound {
+			return err
+		}
+		if duration != nil || time.Now().After(deadline) {
+			return nil
+		}
+
+		t := time.NewTimer(50 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
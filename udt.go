@@ -0,0 +1,78 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+)
+
+// MarshalUDT is a helper for implementing gocql.UDTMarshaler, it marshals
+// the field of v matching the UDT field name. Fields are resolved using
+// DefaultMapper so db tags and snake case names are respected. If v has no
+// such field null is marshalled. To use a custom mapper i.e. the one set as
+// Session.Mapper call MarshalUDTWithMapper.
+//
+// Example:
+//     func (n FullName) MarshalUDT(name string, info gocql.TypeInfo) ([]byte, error) {
+//         return gocqlx.MarshalUDT(n, name, info)
+//     }
+func MarshalUDT(v interface{}, name string, info gocql.TypeInfo) ([]byte, error) {
+	return MarshalUDTWithMapper(defaultMapper(), v, name, info)
+}
+
+// MarshalUDTWithMapper is like MarshalUDT but fields are resolved using m.
+func MarshalUDTWithMapper(m *reflectx.Mapper, v interface{}, name string, info gocql.TypeInfo) ([]byte, error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	index, ok := udtField(m, value, name)
+	if !ok {
+		return nil, nil
+	}
+	return gocql.Marshal(info, reflectx.FieldByIndexesReadOnly(value, index).Interface())
+}
+
+// UnmarshalUDT is a helper for implementing gocql.UDTUnmarshaler, it
+// unmarshals data into the field of v, which must be a pointer, matching the
+// UDT field name. Fields are resolved using DefaultMapper so db tags and snake
+// case names are respected. To use a custom mapper call
+// UnmarshalUDTWithMapper.
+//
+// Example:
+//     func (n *FullName) UnmarshalUDT(name string, info gocql.TypeInfo, data []byte) error {
+//         return gocqlx.UnmarshalUDT(n, name, info, data)
+//     }
+func UnmarshalUDT(v interface{}, name string, info gocql.TypeInfo, data []byte) error {
+	return UnmarshalUDTWithMapper(defaultMapper(), v, name, info, data)
+}
+
+// UnmarshalUDTWithMapper is like UnmarshalUDT but fields are resolved using m.
+func UnmarshalUDTWithMapper(m *reflectx.Mapper, v interface{}, name string, info gocql.TypeInfo, data []byte) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr {
+		return fmt.Errorf("expected a pointer but got %T", v)
+	}
+	value = value.Elem()
+	index, ok := udtField(m, value, name)
+	if !ok {
+		return &MissingDestinationError{Column: name, Type: reflect.TypeOf(v)}
+	}
+	return gocql.Unmarshal(info, data, reflectx.FieldByIndexes(value, index).Addr().Interface())
+}
+
+// udtField returns index of the struct v field mapped by m to the UDT field
+// name.
+func udtField(m *reflectx.Mapper, v reflect.Value, name string) ([]int, bool) {
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+	fi, ok := m.TypeMap(v.Type()).Names[name]
+	if !ok {
+		return nil, false
+	}
+	return fi.Index, true
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+)
+
+type udtAddress struct {
+	StreetName string
+	Zip        string `db:"postal_code"`
+}
+
+func (a udtAddress) MarshalUDT(name string, info gocql.TypeInfo) ([]byte, error) {
+	return MarshalUDT(a, name, info)
+}
+
+func (a *udtAddress) UnmarshalUDT(name string, info gocql.TypeInfo, data []byte) error {
+	return UnmarshalUDT(a, name, info, data)
+}
+
+func TestUDTHelpers(t *testing.T) {
+	text := gocql.NewNativeType(4, gocql.TypeText, "")
+	a := udtAddress{StreetName: "Main", Zip: "00-001"}
+
+	table := []struct {
+		Name  string
+		Field func(a udtAddress) string
+	}{
+		{"street_name", func(a udtAddress) string { return a.StreetName }},
+		{"postal_code", func(a udtAddress) string { return a.Zip }},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			b, err := a.MarshalUDT(test.Name, text)
+			if err != nil {
+				t.Fatal("MarshalUDT() error", err)
+			}
+			var v udtAddress
+			if err := v.UnmarshalUDT(test.Name, text, b); err != nil {
+				t.Fatal("UnmarshalUDT() error", err)
+			}
+			if test.Field(v) != test.Field(a) {
+				t.Fatalf("UnmarshalUDT()=%q expected %q", test.Field(v), test.Field(a))
+			}
+		})
+	}
+
+	t.Run("missing field", func(t *testing.T) {
+		b, err := a.MarshalUDT("city", text)
+		if err != nil {
+			t.Fatal("MarshalUDT() error", err)
+		}
+		if b != nil {
+			t.Fatal("expected null got", b)
+		}
+		var v udtAddress
+		err = v.UnmarshalUDT("city", text, []byte("x"))
+		var e *MissingDestinationError
+		if !errors.As(err, &e) || e.Column != "city" {
+			t.Fatal("expected missing destination error got", err)
+		}
+	})
+
+	t.Run("custom mapper", func(t *testing.T) {
+		m := reflectx.NewMapperFunc("db", strings.ToUpper)
+		b, err := MarshalUDTWithMapper(m, a, "STREETNAME", text)
+		if err != nil {
+			t.Fatal("MarshalUDTWithMapper() error", err)
+		}
+		var v udtAddress
+		if err := UnmarshalUDTWithMapper(m, &v, "STREETNAME", text, b); err != nil {
+			t.Fatal("UnmarshalUDTWithMapper() error", err)
+		}
+		if v.StreetName != a.StreetName {
+			t.Fatalf("UnmarshalUDTWithMapper()=%q expected %q", v.StreetName, a.StreetName)
+		}
+		if err := UnmarshalUDTWithMapper(m, &v, "street_name", text, b); !errors.Is(err, ErrMissingDestination) {
+			t.Fatal("expected missing destination error got", err)
+		}
+	})
+}
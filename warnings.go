@@ -0,0 +1,80 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"strings"
+)
+
+// ExecResult is the result of a query executed with ExecWithResult.
+type ExecResult struct {
+	// Warnings returned by the server for the query i.e. batch size or large
+	// partition warnings.
+	Warnings []string
+}
+
+// LargePartitionWarnings returns the warnings about writes to large
+// partitions, rows or cells.
+func (r ExecResult) LargePartitionWarnings() []string {
+	var out []string
+	for _, w := range r.Warnings {
+		if isLargePartitionWarning(w) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// isLargePartitionWarning returns true if the server warning w reports
+// a large partition, row or cell.
+func isLargePartitionWarning(w string) bool {
+	w = strings.ToLower(w)
+	return strings.Contains(w, "large partition") ||
+		strings.Contains(w, "large row") ||
+		strings.Contains(w, "large cell")
+}
+
+// LargePartitionHandler is a function called when the server returns a large
+// partition, row or cell warning for a query, with the query statement and
+// the warning.
+type LargePartitionHandler func(ctx context.Context, stmt, warning string)
+
+// WithLargePartitionHandler returns a copy of the session that calls h for
+// every large partition, row or cell warning returned by Exec and
+// ExecWithResult of the queries created by the session. This allows to alert
+// on data modeling issues before they become outages.
+func (s Session) WithLargePartitionHandler(h LargePartitionHandler) Session {
+	s.largePartition = h
+	return s
+}
+
+// ExecWithResult executes the query like Exec and returns the result
+// including the server warnings.
+func (q *Queryx) ExecWithResult() (ExecResult, error) {
+	if q.err != nil {
+		return ExecResult{}, q.err
+	}
+	defer q.trackStats()()
+
+	// warnings must be read before the iterator is closed
+	iter := q.Query.Iter()
+	r := ExecResult{Warnings: iter.Warnings()}
+	err := iter.Close()
+
+	q.reportWarnings(r.Warnings)
+	return r, err
+}
+
+func (q *Queryx) reportWarnings(warnings []string) {
+	if q.largePartition == nil {
+		return
+	}
+	for _, w := range warnings {
+		if isLargePartitionWarning(w) {
+			q.largePartition(q.Query.Context(), q.Query.Statement(), w)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+var testWarnings = []string{
+	"Batch modifying 2 partitions is of size 6000 bytes, exceeding specified threshold of 5120 by 880.",
+	"Writing large partition gocqlx_test/t: k (1048576 bytes)",
+	"Writing Large Row gocqlx_test/t: k c (1048576 bytes)",
+}
+
+func TestExecResultLargePartitionWarnings(t *testing.T) {
+	r := ExecResult{Warnings: testWarnings}
+	if diff := cmp.Diff(testWarnings[1:], r.LargePartitionWarnings()); diff != "" {
+		t.Fatal(diff)
+	}
+	if w := (ExecResult{}).LargePartitionWarnings(); w != nil {
+		t.Fatal("expected no warnings got", w)
+	}
+}
+
+func TestLargePartitionHandler(t *testing.T) {
+	type call struct {
+		Stmt    string
+		Warning string
+	}
+	var got []call
+	h := func(ctx context.Context, stmt, warning string) {
+		if ctx == nil {
+			t.Error("expected context")
+		}
+		got = append(got, call{stmt, warning})
+	}
+
+	const stmt = "INSERT INTO t (k,c,v) VALUES (?,?,?) "
+	s := NewSession(&gocql.Session{}).WithLargePartitionHandler(h)
+	q := s.Query(stmt, nil)
+	q.reportWarnings(testWarnings)
+
+	golden := []call{
+		{stmt, testWarnings[1]},
+		{stmt, testWarnings[2]},
+	}
+	if diff := cmp.Diff(golden, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	got = nil
+	q.largePartition = nil
+	q.reportWarnings(testWarnings)
+	if len(got) != 0 {
+		t.Fatal("expected no calls got", got)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"time"
+)
+
+// ZonedTime is a time.Time that keeps its time zone when stored in the
+// database. CQL timestamp is stored as UTC milliseconds and the zone is lost,
+// to preserve it the zone name must be stored in a companion text column.
+//
+// Example:
+//     type Event struct {
+//         ID     int
+//         At     time.Time
+//         AtZone string
+//     }
+//
+//     e.At, e.AtZone = gocqlx.ZonedTime{Time: t}.Marshal()
+//
+//     var z gocqlx.ZonedTime
+//     err := z.Unmarshal(e.At, e.AtZone)
+type ZonedTime struct {
+	time.Time
+}
+
+// Marshal returns the timestamp in UTC and the zone name that shall be stored
+// in the companion column. Time zones without a name, i.e. parsed from an
+// offset, are returned as an offset in the form of "+hh:mm". So is time.Local,
+// its name "Local" would be loaded as the local zone of the reader.
+func (z ZonedTime) Marshal() (ts time.Time, zone string) {
+	zone = z.Location().String()
+	if zone == "" || zone == "Local" {
+		_, offset := z.Zone()
+		zone = formatOffset(offset)
+	}
+	return z.UTC(), zone
+}
+
+// Unmarshal sets the time from a timestamp and a zone name as returned by
+// Marshal. Empty zone is treated as UTC.
+func (z *ZonedTime) Unmarshal(ts time.Time, zone string) error {
+	loc, err := loadLocation(zone)
+	if err != nil {
+		return err
+	}
+	z.Time = ts.In(loc)
+	return nil
+}
+
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+func loadLocation(zone string) (*time.Location, error) {
+	if len(zone) == 6 && (zone[0] == '+' || zone[0] == '-') {
+		var h, m int
+		if _, err := fmt.Sscanf(zone[1:], "%02d:%02d", &h, &m); err == nil {
+			offset := h*3600 + m*60
+			if zone[0] == '-' {
+				offset = -offset
+			}
+			return time.FixedZone(zone, offset), nil
+		}
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %s", zone, err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestZonedTime(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	parsed, err := time.Parse(time.RFC3339, "2020-02-03T10:20:30-03:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := []struct {
+		Name string
+		T    time.Time
+		Zone string
+	}{
+		{
+			Name: "named zone",
+			T:    time.Date(2020, 2, 3, 10, 20, 30, 0, warsaw),
+			Zone: "Europe/Warsaw",
+		},
+		{
+			Name: "utc",
+			T:    time.Date(2020, 2, 3, 10, 20, 30, 0, time.UTC),
+			Zone: "UTC",
+		},
+		{
+			Name: "offset",
+			T:    parsed,
+			Zone: "-03:30",
+		},
+	}
+
+	info := gocql.NewNativeType(4, gocql.TypeTimestamp, "")
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			ts, zone := ZonedTime{Time: test.T}.Marshal()
+			if zone != test.Zone {
+				t.Fatalf("Marshal() zone=%q expected %q", zone, test.Zone)
+			}
+
+			// round-trip timestamp through CQL, it comes back in UTC
+			b, err := gocql.Marshal(info, ts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var v time.Time
+			if err := gocql.Unmarshal(info, b, &v); err != nil {
+				t.Fatal(err)
+			}
+
+			var z ZonedTime
+			if err := z.Unmarshal(v, zone); err != nil {
+				t.Fatal("Unmarshal() error", err)
+			}
+			if !z.Equal(test.T) {
+				t.Fatalf("Unmarshal() time=%s expected %s", z, test.T)
+			}
+			if z.Format(time.RFC3339) != test.T.Format(time.RFC3339) {
+				t.Fatalf("Unmarshal() time=%s expected %s", z.Format(time.RFC3339), test.T.Format(time.RFC3339))
+			}
+		})
+	}
+
+	t.Run("local", func(t *testing.T) {
+		l := time.Date(2020, 2, 3, 10, 20, 30, 0, time.Local)
+		_, offset := l.Zone()
+
+		// time.Local is named after TZ environment variable if set
+		expected := time.Local.String()
+		if expected == "Local" {
+			expected = formatOffset(offset)
+		}
+
+		ts, zone := ZonedTime{Time: l}.Marshal()
+		if zone != expected {
+			t.Fatalf("Marshal() zone=%q expected %q", zone, expected)
+		}
+
+		var z ZonedTime
+		if err := z.Unmarshal(ts, zone); err != nil {
+			t.Fatal("Unmarshal() error", err)
+		}
+		if !z.Equal(l) {
+			t.Fatalf("Unmarshal() time=%s expected %s", z, l)
+		}
+		if _, o := z.Zone(); o != offset {
+			t.Fatalf("Unmarshal() offset=%d expected %d", o, offset)
+		}
+	})
+
+	t.Run("invalid zone", func(t *testing.T) {
+		var z ZonedTime
+		if err := z.Unmarshal(time.Now(), "Nowhere/Never"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}